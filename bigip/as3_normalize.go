@@ -0,0 +1,172 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/efellowsbg/terraform-provider-bigip/schemas"
+)
+
+// as3Defaults holds the property defaults of the AS3 classes, keyed by class name. Monitor defaults
+// that depend on the monitor type are keyed by "Monitor.<monitorType>".
+var as3Defaults struct {
+	once    sync.Once
+	classes map[string]map[string]interface{}
+}
+
+// as3ArrayElementClasses gives the implicit class of the objects in array properties, which have
+// no class property of their own.
+var as3ArrayElementClasses = map[string]map[string]string{
+	"Pool": {"members": "Pool_Member"},
+}
+
+// as3MetadataProperties are the declaration properties left out of the comparison with ignore_metadata.
+var as3MetadataProperties = map[string]bool{
+	"id":            true,
+	"label":         true,
+	"remark":        true,
+	"schemaVersion": true,
+	"updateMode":    true,
+	"Common":        true,
+}
+
+// as3ServerProperties are added by AS3 to the declaration it returns and are only compared when the
+// configured declaration sets them.
+var as3ServerProperties = map[string]map[string]bool{
+	"ADC": {"id": true, "controls": true},
+}
+
+func as3ClassDefaults(class string, object map[string]interface{}) map[string]interface{} {
+	as3Defaults.once.Do(func() {
+		data, err := schemas.FS.ReadFile("as3defaults.json")
+		if err == nil {
+			err = json.Unmarshal(data, &as3Defaults.classes)
+		}
+		if err != nil {
+			log.Printf("[ERROR] Unable to load the embedded AS3 class defaults: %v", err)
+		}
+	})
+	defaults := make(map[string]interface{})
+	for key, value := range as3Defaults.classes[class] {
+		defaults[key] = value
+	}
+	if monitorType, ok := object["monitorType"].(string); ok && class == "Monitor" {
+		for key, value := range as3Defaults.classes["Monitor."+monitorType] {
+			defaults[key] = value
+		}
+	}
+	return defaults
+}
+
+// as3DeclarationDrift compares the declaration returned by AS3 with the configured one and returns the
+// JSON pointers of the differences. Properties that are only set on one side are ignored when they hold
+// the AS3 default of their class, so neither defaults added by AS3 nor defaults written out in the
+// configuration are reported, while properties changed outside of Terraform are.
+func as3DeclarationDrift(configured, observed string, ignoreMetadata bool) ([]string, error) {
+	var desired, actual map[string]interface{}
+	if err := json.Unmarshal([]byte(configured), &desired); err != nil {
+		return nil, fmt.Errorf("unable to parse the configured AS3 declaration: %v", err)
+	}
+	if err := json.Unmarshal([]byte(observed), &actual); err != nil {
+		return nil, fmt.Errorf("unable to parse the AS3 declaration on BIG-IP: %v", err)
+	}
+	drift := as3ObjectDrift("", desired, actual, as3ObjectClass(desired, ""), ignoreMetadata)
+	sort.Strings(drift)
+	return drift, nil
+}
+
+func as3ObjectClass(object map[string]interface{}, implicit string) string {
+	if class, ok := object["class"].(string); ok {
+		return class
+	}
+	return implicit
+}
+
+func as3ObjectDrift(pointer string, desired, actual map[string]interface{}, class string, ignoreMetadata bool) []string {
+	defaults := as3ClassDefaults(class, desired)
+	skip := func(key string) bool {
+		if key == "$schema" || class == "AS3" && key == "persist" {
+			return true
+		}
+		return ignoreMetadata && (class == "ADC" || pointer == "") && as3MetadataProperties[key]
+	}
+	var drift []string
+	for key, value := range desired {
+		if skip(key) {
+			continue
+		}
+		current, ok := actual[key]
+		if !ok {
+			if def, isDefault := defaults[key]; !isDefault || !reflect.DeepEqual(value, def) {
+				drift = append(drift, pointer+"/"+key)
+			}
+			continue
+		}
+		drift = append(drift, as3ValueDrift(pointer+"/"+key, value, current, as3ArrayElementClasses[class][key], ignoreMetadata)...)
+	}
+	for key, value := range actual {
+		if _, ok := desired[key]; ok || skip(key) || as3ServerProperties[class][key] {
+			continue
+		}
+		if def, isDefault := defaults[key]; !isDefault || !reflect.DeepEqual(value, def) {
+			drift = append(drift, pointer+"/"+key)
+		}
+	}
+	return drift
+}
+
+func as3ValueDrift(pointer string, desired, actual interface{}, elementClass string, ignoreMetadata bool) []string {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return []string{pointer}
+		}
+		return as3ObjectDrift(pointer, desiredValue, actualValue, as3ObjectClass(desiredValue, elementClass), ignoreMetadata)
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok || len(actualValue) != len(desiredValue) {
+			return []string{pointer}
+		}
+		var drift []string
+		for i := range desiredValue {
+			drift = append(drift, as3ValueDrift(fmt.Sprintf("%s/%d", pointer, i), desiredValue[i], actualValue[i], elementClass, ignoreMetadata)...)
+		}
+		return drift
+	}
+	if !reflect.DeepEqual(desired, actual) {
+		return []string{pointer}
+	}
+	return nil
+}
+
+// as3JsonDiffSuppress suppresses the as3_json diff when the declarations only differ in AS3 defaults,
+// or in metadata when ignore_metadata is set.
+func as3JsonDiffSuppress(old, new string, ignoreMetadata bool) bool {
+	if old == new {
+		return true
+	}
+	if old == "" || new == "" {
+		return false
+	}
+	drift, err := as3DeclarationDrift(new, old, ignoreMetadata)
+	if err != nil {
+		log.Printf("[DEBUG] Comparing AS3 declarations as text: %v", err)
+		return false
+	}
+	if len(drift) > 0 {
+		log.Printf("[DEBUG] AS3 declaration differs at %s", strings.Join(drift, ", "))
+		return false
+	}
+	return true
+}
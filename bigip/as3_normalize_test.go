@@ -0,0 +1,139 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testAs3ConfiguredDeclaration = `{
+  "class": "AS3",
+  "action": "deploy",
+  "declaration": {
+    "class": "ADC",
+    "schemaVersion": "3.50.0",
+    "Sample_01": {
+      "class": "Tenant",
+      "A1": {
+        "class": "Application",
+        "service": {
+          "class": "Service_HTTP",
+          "virtualAddresses": ["10.0.1.10"],
+          "pool": "web_pool"
+        },
+        "web_pool": {
+          "class": "Pool",
+          "monitors": ["http"],
+          "loadBalancingMode": "round-robin",
+          "members": [{"servicePort": 80, "serverAddresses": ["192.0.1.10"]}]
+        },
+        "http_monitor": {
+          "class": "Monitor",
+          "monitorType": "http",
+          "interval": 10
+        }
+      }
+    }
+  }
+}`
+
+func TestAs3DeclarationDrift(t *testing.T) {
+	// the declaration returned by AS3, with server side defaults and metadata
+	observed := `{
+  "class": "AS3",
+  "action": "deploy",
+  "persist": true,
+  "declaration": {
+    "class": "ADC",
+    "schemaVersion": "3.50.0",
+    "id": "autogen_5f1c1b6e",
+    "updateMode": "selective",
+    "Sample_01": {
+      "class": "Tenant",
+      "defaultRouteDomain": 0,
+      "A1": {
+        "class": "Application",
+        "template": "generic",
+        "service": {
+          "class": "Service_HTTP",
+          "virtualAddresses": ["10.0.1.10"],
+          "virtualPort": 80,
+          "persistenceMethods": ["cookie"],
+          "snat": "auto",
+          "pool": "web_pool"
+        },
+        "web_pool": {
+          "class": "Pool",
+          "monitors": ["http"],
+          "members": [{"servicePort": 80, "serverAddresses": ["192.0.1.10"], "ratio": 1, "enable": true}]
+        },
+        "http_monitor": {
+          "class": "Monitor",
+          "monitorType": "http",
+          "interval": 10,
+          "timeout": 16,
+          "send": "HEAD / HTTP/1.0\r\n\r\n"
+        }
+      }
+    }
+  }
+}`
+	tests := []struct {
+		name           string
+		observed       string
+		ignoreMetadata bool
+		drift          []string
+	}{
+		{
+			name:     "Defaults",
+			observed: observed,
+		},
+		{
+			name:     "Modified member",
+			observed: `{"class": "AS3", "declaration": {"class": "ADC", "schemaVersion": "3.50.0", "Sample_01": {"class": "Tenant", "A1": {"class": "Application", "service": {"class": "Service_HTTP", "virtualAddresses": ["10.0.1.10"], "pool": "web_pool"}, "web_pool": {"class": "Pool", "monitors": ["http"], "members": [{"servicePort": 80, "serverAddresses": ["192.0.1.10"], "ratio": 5}]}, "http_monitor": {"class": "Monitor", "monitorType": "http", "interval": 10}}}}}`,
+			drift:    []string{"/declaration/Sample_01/A1/web_pool/members/0/ratio"},
+		},
+		{
+			name:     "Out of band object",
+			observed: `{"class": "AS3", "declaration": {"class": "ADC", "schemaVersion": "3.50.0", "Sample_01": {"class": "Tenant", "A1": {"class": "Application", "service": {"class": "Service_HTTP", "virtualAddresses": ["10.0.1.10", "10.0.1.11"], "pool": "web_pool"}, "web_pool": {"class": "Pool", "monitors": ["http"], "members": [{"servicePort": 80, "serverAddresses": ["192.0.1.10"]}]}, "http_monitor": {"class": "Monitor", "monitorType": "http", "interval": 10}, "api_pool": {"class": "Pool"}}}}}`,
+			drift:    []string{"/declaration/Sample_01/A1/api_pool", "/declaration/Sample_01/A1/service/virtualAddresses"},
+		},
+		{
+			name:     "Metadata",
+			observed: `{"class": "AS3", "declaration": {"class": "ADC", "schemaVersion": "3.45.0", "label": "edited", "Sample_01": {"class": "Tenant", "A1": {"class": "Application", "service": {"class": "Service_HTTP", "virtualAddresses": ["10.0.1.10"], "pool": "web_pool"}, "web_pool": {"class": "Pool", "monitors": ["http"], "members": [{"servicePort": 80, "serverAddresses": ["192.0.1.10"]}]}, "http_monitor": {"class": "Monitor", "monitorType": "http", "interval": 10}}}}}`,
+			drift:    []string{"/declaration/label", "/declaration/schemaVersion"},
+		},
+		{
+			name:           "Ignored metadata",
+			observed:       `{"class": "AS3", "declaration": {"class": "ADC", "schemaVersion": "3.45.0", "label": "edited", "Sample_01": {"class": "Tenant", "A1": {"class": "Application", "service": {"class": "Service_HTTP", "virtualAddresses": ["10.0.1.10"], "pool": "web_pool"}, "web_pool": {"class": "Pool", "monitors": ["http"], "members": [{"servicePort": 80, "serverAddresses": ["192.0.1.10"]}]}, "http_monitor": {"class": "Monitor", "monitorType": "http", "interval": 10}}}}}`,
+			ignoreMetadata: true,
+		},
+		{
+			name:     "Non default monitor property",
+			observed: `{"class": "AS3", "declaration": {"class": "ADC", "schemaVersion": "3.50.0", "Sample_01": {"class": "Tenant", "A1": {"class": "Application", "service": {"class": "Service_HTTP", "virtualAddresses": ["10.0.1.10"], "pool": "web_pool"}, "web_pool": {"class": "Pool", "monitors": ["http"], "members": [{"servicePort": 80, "serverAddresses": ["192.0.1.10"]}]}, "http_monitor": {"class": "Monitor", "monitorType": "http", "interval": 10, "receive": "200 OK"}}}}}`,
+			drift:    []string{"/declaration/Sample_01/A1/http_monitor/receive"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			drift, err := as3DeclarationDrift(testAs3ConfiguredDeclaration, test.observed, test.ignoreMetadata)
+			assert.NoError(t, err)
+			assert.Equal(t, test.drift, drift)
+		})
+	}
+}
+
+func TestAs3JsonDiffSuppress(t *testing.T) {
+	assert.True(t, as3JsonDiffSuppress(testAs3ConfiguredDeclaration, testAs3ConfiguredDeclaration, false))
+	assert.False(t, as3JsonDiffSuppress("", testAs3ConfiguredDeclaration, false))
+	assert.False(t, as3JsonDiffSuppress(`{"class": "AS3"`, testAs3ConfiguredDeclaration, false))
+
+	perApp := `{"schemaVersion": "3.50.0", "A1": {"class": "Application", "web_pool": {"class": "Pool", "members": [{"servicePort": 80, "serverAddresses": ["192.0.1.10"]}]}}}`
+	observed := `{"schemaVersion": "3.50.0", "A1": {"class": "Application", "template": "generic", "web_pool": {"class": "Pool", "loadBalancingMode": "round-robin", "members": [{"servicePort": 80, "serverAddresses": ["192.0.1.10"], "shareNodes": false}]}}}`
+	assert.True(t, as3JsonDiffSuppress(observed, perApp, false))
+}
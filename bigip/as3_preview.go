@@ -0,0 +1,239 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// as3DryRunQuery makes AS3 compute the changes of a declaration without deploying it, and return
+// the difference between the current and the desired configuration of each tenant.
+const as3DryRunQuery = "?controls.dryRun=true&controls.trace=true&controls.traceResponse=true"
+
+// as3PlannedChange is a BIG-IP object AS3 would create, modify or delete for a declaration.
+type as3PlannedChange struct {
+	tenant      string
+	application string
+	path        string
+	command     string
+	action      string
+	properties  []string
+}
+
+func (c as3PlannedChange) description() string {
+	object := strings.TrimSpace(c.command + " " + c.path)
+	switch c.action {
+	case "create":
+		return object + " created"
+	case "delete":
+		return object + " deleted"
+	case "replace":
+		return object + " replaced"
+	}
+	if len(c.properties) == 0 {
+		return object + " changed"
+	}
+	return fmt.Sprintf("%s %s changed", object, strings.Join(c.properties, ", "))
+}
+
+// as3DiffEntry is an entry of the deep-diff AS3 returns in the traces of a dry-run.
+type as3DiffEntry struct {
+	Kind    string        `json:"kind"`
+	Path    []interface{} `json:"path"`
+	Command string        `json:"command"`
+}
+
+type as3DryRunResponse struct {
+	Results []struct {
+		Code    int         `json:"code"`
+		Message string      `json:"message"`
+		Tenant  string      `json:"tenant"`
+		Errors  interface{} `json:"errors"`
+	} `json:"results"`
+	Traces map[string]json.RawMessage `json:"traces"`
+}
+
+func as3PlannedChangeSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"tenant": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Tenant of the object",
+			},
+			"application": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Application of the object, empty for tenant level objects",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the BIG-IP object",
+			},
+			"command": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "tmsh component of the BIG-IP object, e.g. ltm pool",
+			},
+			"action": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "One of create, modify, replace or delete",
+			},
+			"properties": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Properties of the object that are modified",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Summary of the change, e.g. ltm pool /Tenant/App/web_pool members changed",
+			},
+		},
+	}
+}
+
+// as3DryRun posts the declaration as a dry-run for tenants, a comma separated list that may be empty,
+// and returns the changes AS3 would make.
+func as3DryRun(client *bigip.BigIP, as3Json, tenants string) ([]as3PlannedChange, error) {
+	url := "/mgmt/shared/appsvcs/declare"
+	if tenants != "" {
+		url += "/" + tenants
+	}
+	log.Printf("[DEBUG] Posting AS3 dry-run for tenants (%s)", tenants)
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "post",
+		URL:         url + as3DryRunQuery,
+		Body:        as3Json,
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v%s", err, as3DryRunErrors(resp))
+	}
+	return parseAs3DryRun(resp)
+}
+
+// as3DryRunErrors returns the declaration errors of a failed dry-run response.
+func as3DryRunErrors(resp []byte) string {
+	var failed struct {
+		Errors []string `json:"errors"`
+	}
+	if err := json.Unmarshal(resp, &failed); err != nil || len(failed.Errors) == 0 {
+		return ""
+	}
+	return ": " + strings.Join(failed.Errors, "; ")
+}
+
+func parseAs3DryRun(resp []byte) ([]as3PlannedChange, error) {
+	var dryRun as3DryRunResponse
+	if err := json.Unmarshal(resp, &dryRun); err != nil {
+		return nil, fmt.Errorf("unable to parse AS3 dry-run response: %v", err)
+	}
+	for _, result := range dryRun.Results {
+		if result.Code >= 400 {
+			return nil, fmt.Errorf("AS3 dry-run failed for tenant (%s) with code %d: %s %v", result.Tenant, result.Code, result.Message, result.Errors)
+		}
+	}
+	changes := make(map[string]*as3PlannedChange)
+	for key, trace := range dryRun.Traces {
+		if !strings.HasSuffix(key, "Diff") {
+			continue
+		}
+		var entries []as3DiffEntry
+		if err := json.Unmarshal(trace, &entries); err != nil {
+			return nil, fmt.Errorf("unable to parse AS3 dry-run trace (%s): %v", key, err)
+		}
+		for _, entry := range entries {
+			addAs3PlannedChange(changes, strings.TrimSuffix(key, "Diff"), entry)
+		}
+	}
+	var result []as3PlannedChange
+	for _, change := range changes {
+		sort.Strings(change.properties)
+		result = append(result, *change)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].path < result[j].path
+	})
+	return result, nil
+}
+
+func addAs3PlannedChange(changes map[string]*as3PlannedChange, tenant string, entry as3DiffEntry) {
+	if len(entry.Path) == 0 {
+		return
+	}
+	path, ok := entry.Path[0].(string)
+	if !ok {
+		return
+	}
+	change, ok := changes[path]
+	if !ok {
+		change = &as3PlannedChange{tenant: tenant, path: path, command: entry.Command}
+		if parts := strings.Split(strings.Trim(path, "/"), "/"); len(parts) > 2 {
+			change.application = parts[1]
+		}
+		changes[path] = change
+	}
+	if change.command == "" {
+		change.command = entry.Command
+	}
+
+	action := "modify"
+	if len(entry.Path) == 1 {
+		switch entry.Kind {
+		case "N":
+			action = "create"
+		case "D":
+			action = "delete"
+		}
+	}
+	switch {
+	case change.action == "" || change.action == "modify" && action != "modify":
+		change.action = action
+	case change.action == "create" && action == "delete" || change.action == "delete" && action == "create":
+		change.action = "replace"
+	}
+
+	if len(entry.Path) > 1 {
+		property := entry.Path[1]
+		if property == "properties" && len(entry.Path) > 2 {
+			property = entry.Path[2]
+		}
+		name := fmt.Sprintf("%v", property)
+		for _, existing := range change.properties {
+			if existing == name {
+				return
+			}
+		}
+		change.properties = append(change.properties, name)
+	}
+}
+
+func flattenAs3PlannedChanges(changes []as3PlannedChange) []interface{} {
+	result := make([]interface{}, 0, len(changes))
+	for _, change := range changes {
+		result = append(result, map[string]interface{}{
+			"tenant":      change.tenant,
+			"application": change.application,
+			"path":        change.path,
+			"command":     change.command,
+			"action":      change.action,
+			"properties":  change.properties,
+			"description": change.description(),
+		})
+	}
+	return result
+}
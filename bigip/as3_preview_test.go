@@ -0,0 +1,83 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAs3DryRunResponse = `{
+  "results": [
+    {"code": 200, "message": "success", "tenant": "Sample_01", "dryRun": true}
+  ],
+  "traces": {
+    "Sample_01Desired": {},
+    "Sample_01Diff": [
+      {"kind": "E", "path": ["/Sample_01/A1/web_pool", "properties", "members", "/Sample_01/192.0.1.12:80"], "command": "ltm pool"},
+      {"kind": "E", "path": ["/Sample_01/A1/web_pool", "properties", "load-balancing-mode"], "command": "ltm pool"},
+      {"kind": "N", "path": ["/Sample_01/A1/api_pool"], "command": "ltm pool"},
+      {"kind": "D", "path": ["/Sample_01/A1/old_monitor"], "command": "ltm monitor http"},
+      {"kind": "D", "path": ["/Sample_01/A1/service"], "command": "ltm virtual"},
+      {"kind": "N", "path": ["/Sample_01/A1/service"], "command": "ltm virtual"}
+    ]
+  }
+}`
+
+func TestParseAs3DryRun(t *testing.T) {
+	changes, err := parseAs3DryRun([]byte(testAs3DryRunResponse))
+	assert.NoError(t, err)
+	assert.Equal(t, []as3PlannedChange{
+		{tenant: "Sample_01", application: "A1", path: "/Sample_01/A1/api_pool", command: "ltm pool", action: "create"},
+		{tenant: "Sample_01", application: "A1", path: "/Sample_01/A1/old_monitor", command: "ltm monitor http", action: "delete"},
+		{tenant: "Sample_01", application: "A1", path: "/Sample_01/A1/service", command: "ltm virtual", action: "replace"},
+		{tenant: "Sample_01", application: "A1", path: "/Sample_01/A1/web_pool", command: "ltm pool", action: "modify", properties: []string{"load-balancing-mode", "members"}},
+	}, changes)
+	assert.Equal(t, "ltm pool /Sample_01/A1/web_pool load-balancing-mode, members changed", changes[3].description())
+	assert.Equal(t, "ltm pool /Sample_01/A1/api_pool created", changes[0].description())
+
+	_, err = parseAs3DryRun([]byte(`{"results": [{"code": 422, "message": "declaration is invalid", "tenant": "Sample_01"}]}`))
+	assert.Error(t, err)
+
+	changes, err = parseAs3DryRun([]byte(`{"results": [{"code": 200, "message": "no change", "tenant": "Sample_01"}]}`))
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestReadAppliedBigipAs3(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/shared/appsvcs/declare/Sample_01", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"class": "ADC", "schemaVersion": "3.50.0", "Sample_01": {"class": "Tenant"}}`)
+	})
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+	changes, err := parseAs3DryRun([]byte(testAs3DryRunResponse))
+	assert.NoError(t, err)
+	d := schema.TestResourceDataRaw(t, resourceBigipAs3().Schema, map[string]interface{}{
+		"as3_json":        `{"class": "AS3", "declaration": {"class": "ADC", "schemaVersion": "3.50.0", "Sample_01": {"class": "Tenant"}}}`,
+		"preview_changes": true,
+	})
+	d.SetId("Sample_01")
+	assert.NoError(t, d.Set("planned_changes", flattenAs3PlannedChanges(changes)))
+
+	// the apply keeps the planned value, so that the state matches the plan
+	assert.False(t, readAppliedBigipAs3(context.Background(), d, client).HasError())
+	assert.Len(t, d.Get("planned_changes"), len(changes))
+
+	// the next refresh empties it
+	assert.False(t, resourceBigipAs3Read(context.Background(), d, client).HasError())
+	assert.Empty(t, d.Get("planned_changes"))
+}
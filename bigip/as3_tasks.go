@@ -0,0 +1,275 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+)
+
+const (
+	as3TaskURL    = "/mgmt/shared/appsvcs/task"
+	as3DeclareURL = "/mgmt/shared/appsvcs/declare"
+)
+
+var (
+	as3TaskPollInterval = 3 * time.Second
+	as3BusyMinBackoff   = 2 * time.Second
+	as3BusyMaxBackoff   = 30 * time.Second
+)
+
+// as3TenantLocks serializes the AS3 and FAST operations on a tenant of a BIG-IP, so that declarations for
+// disjoint tenants are deployed concurrently while two resources never change the same tenant at once.
+var as3TenantLocks = struct {
+	sync.Mutex
+	locks map[string]*sync.Mutex
+}{locks: make(map[string]*sync.Mutex)}
+
+// lockAs3Tenants locks the tenants of the client's host, given as names or comma separated lists, and
+// returns the function releasing them. Locks are taken in a fixed order to avoid deadlocks between
+// resources sharing several tenants.
+func lockAs3Tenants(client *bigip.BigIP, tenants ...string) func() {
+	keys := make(map[string]bool)
+	for _, list := range tenants {
+		for _, tenant := range strings.Split(list, ",") {
+			if tenant = strings.TrimSpace(tenant); tenant != "" {
+				keys[client.Host+"/"+tenant] = true
+			}
+		}
+	}
+	sorted := make([]string, 0, len(keys))
+	for key := range keys {
+		sorted = append(sorted, key)
+	}
+	sort.Strings(sorted)
+
+	locks := make([]*sync.Mutex, 0, len(sorted))
+	as3TenantLocks.Lock()
+	for _, key := range sorted {
+		lock, ok := as3TenantLocks.locks[key]
+		if !ok {
+			lock = &sync.Mutex{}
+			as3TenantLocks.locks[key] = lock
+		}
+		locks = append(locks, lock)
+	}
+	as3TenantLocks.Unlock()
+
+	log.Printf("[DEBUG] Locking AS3 tenants %v", sorted)
+	for _, lock := range locks {
+		lock.Lock()
+	}
+	return func() {
+		for i := len(locks) - 1; i >= 0; i-- {
+			locks[i].Unlock()
+		}
+		log.Printf("[DEBUG] Unlocked AS3 tenants %v", sorted)
+	}
+}
+
+type as3TaskResult struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Tenant  string      `json:"tenant"`
+	Errors  interface{} `json:"errors,omitempty"`
+}
+
+// as3Task is the state of an asynchronous AS3 request as returned by /mgmt/shared/appsvcs/task.
+type as3Task struct {
+	ID      string          `json:"id"`
+	Results []as3TaskResult `json:"results"`
+}
+
+// pending reports whether AS3 is still processing the task, which it signals with code 0.
+func (t *as3Task) pending() bool {
+	if len(t.Results) == 0 {
+		return true
+	}
+	for _, result := range t.Results {
+		if result.Code == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// busy reports whether AS3 rejected the task because another asynchronous task was running.
+func (t *as3Task) busy() bool {
+	for _, result := range t.Results {
+		if result.Code == 503 || isAs3BusyMessage(result.Message) {
+			return true
+		}
+	}
+	return false
+}
+
+// tenants returns the tenants whose result satisfies ok.
+func (t *as3Task) tenants(ok func(code int) bool) []string {
+	var tenants []string
+	for _, result := range t.Results {
+		if result.Tenant != "" && ok(result.Code) {
+			tenants = append(tenants, result.Tenant)
+		}
+	}
+	return tenants
+}
+
+func (t *as3Task) String() string {
+	out, _ := json.MarshalIndent(t.Results, "", "\t")
+	return string(out)
+}
+
+func isAs3BusyMessage(message string) bool {
+	message = strings.ToLower(message)
+	return strings.Contains(message, "active asynchronous task") || strings.Contains(message, "configuration operation in progress")
+}
+
+func isAs3Busy(err error) bool {
+	return err != nil && (strings.Contains(err.Error(), "503") || isAs3BusyMessage(err.Error()) || strings.Contains(err.Error(), "service unavailable"))
+}
+
+// submitAs3Task sends an asynchronous AS3 request and waits for its task to complete. Requests rejected
+// because AS3 is processing another task are resubmitted with an exponential backoff until ctx expires.
+func submitAs3Task(ctx context.Context, client *bigip.BigIP, method, url, body string) (*as3Task, error) {
+	if strings.Contains(url, "?") {
+		url = strings.Replace(url, "?", "?async=true&", 1)
+	} else {
+		url += "?async=true"
+	}
+	backoff := as3BusyMinBackoff
+	for {
+		log.Printf("[DEBUG] Submitting AS3 %s task %s", method, url)
+		resp, err := client.APICall(&bigip.APIRequest{
+			Method:      method,
+			URL:         url,
+			Body:        body,
+			ContentType: "application/json",
+		})
+		if err == nil {
+			var task as3Task
+			if err := json.Unmarshal(resp, &task); err != nil || task.ID == "" {
+				return nil, fmt.Errorf("unable to read the AS3 task from the response %s: %v", string(resp), err)
+			}
+			waited, err := waitAs3Task(ctx, client, task.ID)
+			if err != nil || !waited.busy() {
+				return waited, err
+			}
+			log.Printf("[WARN] AS3 task %s was rejected because another task is active", task.ID)
+		} else if !isAs3Busy(err) {
+			return nil, fmt.Errorf("%v%s", err, as3DryRunErrors(resp))
+		}
+		log.Printf("[INFO] AS3 is busy, resubmitting the request in %s", backoff)
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("timed out waiting for AS3 to process the active asynchronous task: %v", ctx.Err())
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > as3BusyMaxBackoff {
+			backoff = as3BusyMaxBackoff
+		}
+	}
+}
+
+// waitAs3Task polls the AS3 task until it is no longer pending.
+func waitAs3Task(ctx context.Context, client *bigip.BigIP, id string) (*as3Task, error) {
+	for {
+		resp, err := client.APICall(&bigip.APIRequest{
+			Method:      "get",
+			URL:         as3TaskURL + "/" + id,
+			ContentType: "application/json",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reading AS3 task (%s): %v", id, err)
+		}
+		task := &as3Task{ID: id}
+		if err := json.Unmarshal(resp, task); err != nil {
+			return nil, fmt.Errorf("error parsing AS3 task (%s): %v", id, err)
+		}
+		if !task.pending() {
+			log.Printf("[DEBUG] AS3 task %s completed: %s", id, task)
+			return task, nil
+		}
+		select {
+		case <-ctx.Done():
+			return task, fmt.Errorf("timed out waiting for AS3 task (%s): %v", id, ctx.Err())
+		case <-time.After(as3TaskPollInterval):
+		}
+	}
+}
+
+// deployAs3Tenants posts the declaration for the tenants, a comma separated list, and returns the task ID
+// and the tenants deployed successfully, which are also returned with the error of a partial success.
+func deployAs3Tenants(ctx context.Context, client *bigip.BigIP, as3Json, tenants, query string) (string, string, error) {
+	url := as3DeclareURL
+	if tenants != "" {
+		url += "/" + tenants
+	}
+	if query != "" {
+		url += "?" + strings.TrimPrefix(query, "&")
+	}
+	task, err := submitAs3Task(ctx, client, "post", url, as3Json)
+	if err != nil {
+		if task != nil {
+			return task.ID, "", err
+		}
+		return "", "", err
+	}
+	if len(task.Results) == 1 && task.Results[0].Message == "declaration is invalid" {
+		return task.ID, "", fmt.Errorf("declaration is invalid: %+v", task.Results[0].Errors)
+	}
+	succeeded := strings.Join(task.tenants(func(code int) bool { return code == 200 }), ",")
+	for _, result := range task.Results {
+		if result.Code >= 400 {
+			return task.ID, succeeded, fmt.Errorf("as3 config post error response %s", task)
+		}
+	}
+	return task.ID, succeeded, nil
+}
+
+// deployAs3Applications posts a per-application declaration to the tenant and returns the task ID.
+func deployAs3Applications(ctx context.Context, client *bigip.BigIP, as3Json, tenant, query string) (string, error) {
+	url := fmt.Sprintf("%s/%s/applications", as3DeclareURL, tenant)
+	if query != "" {
+		url += "?" + strings.TrimPrefix(query, "&")
+	}
+	task, err := submitAs3Task(ctx, client, "post", url, as3Json)
+	if err != nil {
+		if task != nil {
+			return task.ID, err
+		}
+		return "", err
+	}
+	for _, result := range task.Results {
+		if result.Code >= 400 {
+			return task.ID, fmt.Errorf("tenant Creation failed. Response: %s", task)
+		}
+	}
+	return task.ID, nil
+}
+
+// deleteAs3Tenants removes the tenants, a comma separated list, and returns the tenants that could not be
+// removed with the error.
+func deleteAs3Tenants(ctx context.Context, client *bigip.BigIP, tenants string) (string, error) {
+	task, err := submitAs3Task(ctx, client, "delete", as3DeclareURL+"/"+tenants, "")
+	if err != nil {
+		return "", err
+	}
+	if failed := task.tenants(func(code int) bool { return code >= 400 }); len(failed) > 0 {
+		return strings.Join(failed, ","), fmt.Errorf("tenant Deletion failed with Response: %s", task)
+	}
+	if len(task.Results) > 0 && task.Results[0].Code >= 400 {
+		return tenants, fmt.Errorf("tenant Deletion failed with Response: %s", task)
+	}
+	return "", nil
+}
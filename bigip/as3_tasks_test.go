@@ -0,0 +1,131 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLockAs3Tenants(t *testing.T) {
+	client := &bigip.BigIP{Host: "https://bigip1"}
+	other := &bigip.BigIP{Host: "https://bigip2"}
+
+	unlock := lockAs3Tenants(client, "Tenant_A,Tenant_B")
+
+	acquired := make(chan string, 3)
+	go func() {
+		defer lockAs3Tenants(client, "Tenant_C")()
+		acquired <- "disjoint"
+	}()
+	go func() {
+		defer lockAs3Tenants(other, "Tenant_A")()
+		acquired <- "other host"
+	}()
+	go func() {
+		defer lockAs3Tenants(client, "Tenant_B", "Tenant_C")()
+		acquired <- "shared"
+	}()
+
+	assert.ElementsMatch(t, []string{"disjoint", "other host"}, []string{<-acquired, <-acquired})
+	select {
+	case name := <-acquired:
+		t.Fatalf("lock on %s acquired while Tenant_B was locked", name)
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	assert.Equal(t, "shared", <-acquired)
+}
+
+func TestDeployAs3Tenants(t *testing.T) {
+	as3TaskPollInterval, as3BusyMinBackoff = time.Millisecond, time.Millisecond
+	defer func() {
+		as3TaskPollInterval, as3BusyMinBackoff = 3*time.Second, 2*time.Second
+	}()
+	setup()
+	defer teardown()
+
+	var posts, polls int32
+	mux.HandleFunc("/mgmt/shared/appsvcs/declare/Sample_01,Sample_02", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "true", r.URL.Query().Get("async"))
+		assert.Equal(t, "true", r.URL.Query().Get("controls.trace"))
+		_, _ = fmt.Fprintf(w, `{"id": "task-%d", "results": [{"message": "Declaration successfully submitted", "code": 0}]}`, atomic.AddInt32(&posts, 1))
+	})
+	mux.HandleFunc("/mgmt/shared/appsvcs/task/task-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id": "task-1", "results": [{"message": "Error: There is an active asynchronous task executing.", "code": 503}]}`)
+	})
+	mux.HandleFunc("/mgmt/shared/appsvcs/task/task-2", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&polls, 1) == 1 {
+			_, _ = fmt.Fprint(w, `{"id": "task-2", "results": [{"message": "in progress", "code": 0}]}`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"id": "task-2", "results": [{"message": "success", "tenant": "Sample_01", "code": 200}, {"message": "declaration failed", "tenant": "Sample_02", "code": 422}]}`)
+	})
+
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+	taskID, succeeded, err := deployAs3Tenants(context.Background(), client, `{"class": "AS3"}`, "Sample_01,Sample_02", "&controls.trace=true")
+	assert.Error(t, err)
+	assert.Equal(t, "task-2", taskID)
+	assert.Equal(t, "Sample_01", succeeded)
+	assert.Equal(t, int32(2), posts)
+	assert.Equal(t, int32(2), polls)
+}
+
+func TestResourceBigipAs3DeleteFailedTenants(t *testing.T) {
+	as3TaskPollInterval = time.Millisecond
+	defer func() {
+		as3TaskPollInterval = 3 * time.Second
+	}()
+	setup()
+	defer teardown()
+
+	// the tenants of the declaration are listed in any order
+	mux.HandleFunc("/mgmt/shared/appsvcs/declare/", func(w http.ResponseWriter, r *http.Request) {
+		switch tenants := strings.TrimPrefix(r.URL.Path, "/mgmt/shared/appsvcs/declare/"); tenants {
+		case "Sample_01,Sample_02", "Sample_02,Sample_01":
+			assert.Equal(t, "DELETE", r.Method)
+			_, _ = fmt.Fprint(w, `{"id": "task-1", "results": [{"message": "Declaration successfully submitted", "code": 0}]}`)
+		case "Sample_02":
+			assert.Equal(t, "GET", r.Method)
+			_, _ = fmt.Fprint(w, `{"class": "ADC", "schemaVersion": "3.50.0", "Sample_02": {"class": "Tenant"}}`)
+		default:
+			t.Errorf("unexpected AS3 request %s %s", r.Method, tenants)
+		}
+	})
+	mux.HandleFunc("/mgmt/shared/appsvcs/task/task-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id": "task-1", "results": [{"message": "success", "tenant": "Sample_01", "code": 200}, {"message": "declaration failed", "tenant": "Sample_02", "code": 422}]}`)
+	})
+
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+	d := schema.TestResourceDataRaw(t, resourceBigipAs3().Schema, map[string]interface{}{
+		"as3_json": `{"class": "AS3", "declaration": {"class": "ADC", "schemaVersion": "3.50.0", "Sample_01": {"class": "Tenant"}, "Sample_02": {"class": "Tenant"}}}`,
+	})
+	d.SetId("Sample_01,Sample_02")
+
+	diags := resourceBigipAs3Delete(context.Background(), d, client)
+	assert.True(t, diags.HasError())
+	// the failed tenant stays in the state, so that it is deleted again by the next apply
+	assert.Equal(t, "Sample_01,Sample_02", d.Id())
+	assert.Equal(t, "Sample_02", d.Get("tenant_list"))
+	assert.Contains(t, d.Get("as3_json"), "Sample_02")
+	assert.NotContains(t, d.Get("as3_json"), "Sample_01")
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	bigiqLoginURL    = "mgmt/shared/authn/login"
+	bigiqExchangeURL = "mgmt/shared/authn/exchange"
+	bigiqTokensURL   = "mgmt/shared/authz/tokens"
+)
+
+// bigiqTokenRefreshMargin is how long before its expiry a token is refreshed, so that requests started with
+// it do not fail.
+var bigiqTokenRefreshMargin = time.Minute
+
+// bigiqProviderSchema is the bigiq block of the provider.
+func bigiqProviderSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "BIG-IQ managing the BIG-IPs, shared by the BIG-IQ resources and data sources",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"address": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Domain name/IP of the BIG-IQ",
+					DefaultFunc: schema.EnvDefaultFunc("BIGIQ_HOST", nil),
+				},
+				"port": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Management Port to connect to the BIG-IQ",
+					DefaultFunc: schema.EnvDefaultFunc("BIGIQ_PORT", nil),
+				},
+				"username": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Username with API access to the BIG-IQ",
+					DefaultFunc: schema.EnvDefaultFunc("BIGIQ_USER", nil),
+				},
+				"password": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Sensitive:   true,
+					Description: "The user's password",
+					DefaultFunc: schema.EnvDefaultFunc("BIGIQ_PASSWORD", nil),
+				},
+				"token_auth": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "Enable to use token authentication. Can be set via the BIGIQ_TOKEN_AUTH environment variable",
+					DefaultFunc: schema.EnvDefaultFunc("BIGIQ_TOKEN_AUTH", true),
+				},
+				"login_ref": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Login reference for token authentication (see BIG-IQ REST docs for details)",
+					DefaultFunc: schema.EnvDefaultFunc("BIGIQ_LOGIN_REF", "local"),
+				},
+				"validate_certs_disable": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Description: "If set to true, Disables TLS certificate check on BIG-IQ. Default : True",
+					DefaultFunc: schema.EnvDefaultFunc("BIGIQ_VERIFY_CERT_DISABLE", true),
+				},
+				"trusted_cert_path": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Valid Trusted Certificate path",
+					DefaultFunc: schema.EnvDefaultFunc("BIGIQ_TRUSTED_CERT_PATH", nil),
+				},
+			},
+		},
+	}
+}
+
+// bigiqSession is the session to the BIG-IQ of the provider, shared by all the BIG-IQ resources.
+type bigiqSession struct {
+	mu           sync.Mutex
+	config       bigip.Config
+	client       *bigip.BigIP
+	refreshToken string
+	expires      time.Time
+}
+
+func newBigiqSession(d *schema.ResourceData, configOptions *bigip.ConfigOptions) (*bigiqSession, error) {
+	config := bigip.Config{
+		Address:           d.Get("bigiq.0.address").(string),
+		Port:              d.Get("bigiq.0.port").(string),
+		Username:          d.Get("bigiq.0.username").(string),
+		Password:          d.Get("bigiq.0.password").(string),
+		CertVerifyDisable: d.Get("bigiq.0.validate_certs_disable").(bool),
+		ConfigOptions:     configOptions,
+	}
+	if d.Get("bigiq.0.token_auth").(bool) {
+		config.LoginReference = d.Get("bigiq.0.login_ref").(string)
+	}
+	if !config.CertVerifyDisable {
+		if d.Get("bigiq.0.trusted_cert_path").(string) == "" {
+			return nil, fmt.Errorf("valid Trust Certificate path not provided using :%+v ", "bigiq.trusted_cert_path")
+		}
+		config.TrustedCertificate = d.Get("bigiq.0.trusted_cert_path").(string)
+	}
+	return &bigiqSession{config: config}, nil
+}
+
+// Client returns a client authenticated to the BIG-IQ. The session logs in on first use and refreshes the
+// token when it is about to expire. A new token is set on a new client, so the clients already returned are
+// left untouched while they are in use.
+func (s *bigiqSession) Client() (*bigip.BigIP, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.client != nil && (s.config.LoginReference == "" || time.Until(s.expires) > bigiqTokenRefreshMargin) {
+		return s.client, nil
+	}
+	if s.config.LoginReference == "" {
+		config := s.config
+		client, err := Client(&config)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to BIG-IQ %s: %v", s.config.Address, err)
+		}
+		s.client = client
+		return client, nil
+	}
+	if s.refreshToken != "" {
+		err := s.authenticate(bigiqExchangeURL, map[string]interface{}{"refreshToken": map[string]string{"token": s.refreshToken}})
+		if err == nil {
+			return s.client, nil
+		}
+		log.Printf("[WARN] Unable to refresh the BIG-IQ token, logging in again: %v", err)
+	}
+	err := s.authenticate(bigiqLoginURL, map[string]string{
+		"username":          s.config.Username,
+		"password":          s.config.Password,
+		"loginProviderName": s.config.LoginReference,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error logging in to BIG-IQ %s: %v", s.config.Address, err)
+	}
+	return s.client, nil
+}
+
+// authenticate gets a token from url, the login or the refresh token exchange, and sets it on a new client.
+func (s *bigiqSession) authenticate(url string, body interface{}) error {
+	client, err := s.newClient()
+	if err != nil {
+		return err
+	}
+	authJson, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "post",
+		URL:         url,
+		Body:        string(authJson),
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return err
+	}
+	var auth struct {
+		Token struct {
+			Token   string `json:"token"`
+			Timeout int64  `json:"timeout"`
+		} `json:"token"`
+		RefreshToken struct {
+			Token string `json:"token"`
+		} `json:"refreshToken"`
+	}
+	if err := json.Unmarshal(resp, &auth); err != nil || auth.Token.Token == "" {
+		return fmt.Errorf("unable to acquire authentication token: %v", err)
+	}
+	client.Token = auth.Token.Token
+	if auth.RefreshToken.Token != "" {
+		s.refreshToken = auth.RefreshToken.Token
+	}
+	timeout := time.Duration(auth.Token.Timeout) * time.Second
+	if want := s.config.ConfigOptions.TokenTimeout; want > timeout {
+		// clients are kept by resources waiting on long tasks, so the token is extended to the token_timeout
+		// of the provider when the BIG-IQ allows it
+		if err := extendBigiqToken(client, want); err != nil {
+			log.Printf("[WARN] Unable to extend the BIG-IQ token timeout to %s: %v", want, err)
+		} else {
+			timeout = want
+		}
+	}
+	log.Printf("[DEBUG] Authenticated to BIG-IQ %s, the token expires in %s", client.Host, timeout)
+	s.client = client
+	s.expires = time.Now().Add(timeout)
+	return nil
+}
+
+func (s *bigiqSession) newClient() (*bigip.BigIP, error) {
+	config := s.config
+	client := bigip.NewSession(&config)
+	if !config.CertVerifyDisable {
+		rootCAs, _ := x509.SystemCertPool()
+		if rootCAs == nil {
+			rootCAs = x509.NewCertPool()
+		}
+		certPEM, err := os.ReadFile(config.TrustedCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("provide Valid Trusted certificate path :%+v", err)
+		}
+		if ok := rootCAs.AppendCertsFromPEM(certPEM); !ok {
+			log.Println("[DEBUG] No certs appended, using only system certs")
+		}
+		client.Transport.TLSClientConfig.RootCAs = rootCAs
+	}
+	return client, nil
+}
+
+func extendBigiqToken(client *bigip.BigIP, timeout time.Duration) error {
+	_, err := client.APICall(&bigip.APIRequest{
+		Method:      "patch",
+		URL:         bigiqTokensURL + "/" + client.Token,
+		Body:        fmt.Sprintf(`{"timeout": %d}`, int64(timeout.Seconds())),
+		ContentType: "application/json",
+	})
+	return err
+}
+
+// bigiqSessions holds the BIG-IQ session of each configured provider, keyed by its BIG-IP client, the meta
+// passed to the resources.
+var bigiqSessions = struct {
+	sync.Mutex
+	sessions map[*bigip.BigIP]*bigiqSession
+}{sessions: make(map[*bigip.BigIP]*bigiqSession)}
+
+func setBigiqSession(client *bigip.BigIP, session *bigiqSession) {
+	bigiqSessions.Lock()
+	defer bigiqSessions.Unlock()
+	bigiqSessions.sessions[client] = session
+}
+
+// providerBigiqSession returns the BIG-IQ session of the provider, or nil when the provider has no bigiq block.
+func providerBigiqSession(meta interface{}) *bigiqSession {
+	client, ok := meta.(*bigip.BigIP)
+	if !ok {
+		return nil
+	}
+	bigiqSessions.Lock()
+	defer bigiqSessions.Unlock()
+	return bigiqSessions.sessions[client]
+}
+
+// bigiqClient returns a client of the BIG-IQ of the provider, for the resources managed only through it.
+func bigiqClient(meta interface{}) (*bigip.BigIP, error) {
+	session := providerBigiqSession(meta)
+	if session == nil {
+		return nil, fmt.Errorf("BIG-IQ is not configured: set the bigiq block of the provider")
+	}
+	return session.Client()
+}
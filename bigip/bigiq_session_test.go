@@ -0,0 +1,126 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBigiqSession(tokenTimeout time.Duration) *bigiqSession {
+	return &bigiqSession{config: bigip.Config{
+		Address:           server.URL,
+		Username:          "admin",
+		Password:          "secret",
+		LoginReference:    "local",
+		CertVerifyDisable: true,
+		ConfigOptions:     &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1, TokenTimeout: tokenTimeout},
+	}}
+}
+
+func TestBigiqSessionRefresh(t *testing.T) {
+	setup()
+	defer teardown()
+	logins, exchanges := 0, 0
+	exchangeFails := false
+	mux.HandleFunc("/mgmt/shared/authn/login", func(w http.ResponseWriter, r *http.Request) {
+		var login map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&login))
+		assert.Equal(t, "local", login["loginProviderName"])
+		logins++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"token": {"token": "login-%d", "timeout": 30}, "refreshToken": {"token": "refresh-%d"}}`, logins, logins)
+	})
+	mux.HandleFunc("/mgmt/shared/authn/exchange", func(w http.ResponseWriter, r *http.Request) {
+		var exchange struct {
+			RefreshToken struct {
+				Token string `json:"token"`
+			} `json:"refreshToken"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&exchange))
+		assert.Equal(t, fmt.Sprintf("refresh-%d", logins), exchange.RefreshToken.Token)
+		w.Header().Set("Content-Type", "application/json")
+		if exchangeFails {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = fmt.Fprint(w, `{"code": 401, "message": "Invalid refresh token"}`)
+			return
+		}
+		exchanges++
+		_, _ = fmt.Fprintf(w, `{"token": {"token": "exchange-%d", "timeout": 300}}`, exchanges)
+	})
+
+	session := testBigiqSession(0)
+	client, err := session.Client()
+	assert.NoError(t, err)
+	assert.Equal(t, "login-1", client.Token)
+
+	// the token expires within the refresh margin, so it is exchanged for a new one, set on a new client
+	refreshed, err := session.Client()
+	assert.NoError(t, err)
+	assert.Equal(t, "exchange-1", refreshed.Token)
+	assert.Equal(t, "login-1", client.Token)
+
+	same, err := session.Client()
+	assert.NoError(t, err)
+	assert.Same(t, refreshed, same)
+	assert.Equal(t, 1, logins)
+
+	session.expires = time.Now()
+	exchangeFails = true
+	client, err = session.Client()
+	assert.NoError(t, err)
+	assert.Equal(t, "login-2", client.Token, "a session whose refresh token is rejected logs in again")
+}
+
+func TestBigiqSessionExtendToken(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/shared/authn/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"token": {"token": "token-1", "timeout": 300}, "refreshToken": {"token": "refresh-1"}}`)
+	})
+	mux.HandleFunc("/mgmt/shared/authz/tokens/token-1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, "token-1", r.Header.Get("X-F5-Auth-Token"))
+		var timeout map[string]int
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&timeout))
+		assert.Equal(t, 1200, timeout["timeout"])
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"token": "token-1", "timeout": 1200}`)
+	})
+
+	session := testBigiqSession(1200 * time.Second)
+	_, err := session.Client()
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(1200*time.Second), session.expires, time.Minute)
+}
+
+func TestConnectBigIqProviderSession(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/shared/authn/login", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"token": {"token": "token-1", "timeout": 300}}`)
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceBigiqAs3().Schema, map[string]interface{}{"as3_json": testBigiqAs3Json})
+	meta := bigip.NewSession(&bigip.Config{Address: "bigip.example.com"})
+	_, err := connectBigIq(d, meta)
+	assert.ErrorContains(t, err, "BIG-IQ is not configured")
+
+	setBigiqSession(meta, testBigiqSession(0))
+	client, err := connectBigIq(d, meta)
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL, client.Host)
+	assert.Equal(t, "token-1", client.Token)
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+)
+
+const (
+	bigiqDeviceTrustURL      = "/mgmt/cm/global/tasks/device-trust"
+	bigiqDeviceDiscoveryURL  = "/mgmt/cm/global/tasks/device-discovery"
+	bigiqDeviceImportURL     = "/mgmt/cm/global/tasks/device-import"
+	bigiqRemoveAuthorityURL  = "/mgmt/cm/global/tasks/device-remove-mgmt-authority"
+	bigiqRemoveTrustURL      = "/mgmt/cm/global/tasks/device-remove-trust"
+	bigiqMachineIdResolver   = "https://localhost/mgmt/cm/system/machineid-resolver/"
+	bigiqDeployConfiguration = "/mgmt/cm/%s/tasks/deploy-configuration"
+)
+
+var bigiqTaskPollInterval = 5 * time.Second
+
+// bigiqTask is the state of a BIG-IQ task, e.g. /mgmt/cm/global/tasks/device-trust/{id}.
+type bigiqTask struct {
+	ID           string `json:"id"`
+	Status       string `json:"status"`
+	CurrentStep  string `json:"currentStep"`
+	ErrorMessage string `json:"errorMessage"`
+	MachineID    string `json:"machineId"`
+}
+
+// runBigiqTask posts a task to url and polls it until it finishes. A task that fails is returned with an error.
+func runBigiqTask(ctx context.Context, client *bigip.BigIP, url string, body interface{}) (*bigiqTask, error) {
+	taskJson, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] Submitting BIG-IQ task %s", url)
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "post",
+		URL:         url,
+		Body:        string(taskJson),
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error submitting BIG-IQ task %s: %v", url, err)
+	}
+	task := &bigiqTask{}
+	if err := json.Unmarshal(resp, task); err != nil || task.ID == "" {
+		return nil, fmt.Errorf("unable to read the BIG-IQ task from the response %s: %v", string(resp), err)
+	}
+	for {
+		switch task.Status {
+		case "FINISHED":
+			log.Printf("[DEBUG] BIG-IQ task %s/%s finished", url, task.ID)
+			return task, nil
+		case "FAILED", "CANCELED":
+			return task, fmt.Errorf("BIG-IQ task %s/%s %s at step %s: %s", url, task.ID, task.Status, task.CurrentStep, task.ErrorMessage)
+		}
+		select {
+		case <-ctx.Done():
+			return task, fmt.Errorf("timed out waiting for BIG-IQ task %s/%s, last status %s: %v", url, task.ID, task.Status, ctx.Err())
+		case <-time.After(bigiqTaskPollInterval):
+		}
+		resp, err := client.APICall(&bigip.APIRequest{
+			Method:      "get",
+			URL:         url + "/" + task.ID,
+			ContentType: "application/json",
+		})
+		if err != nil {
+			return task, fmt.Errorf("error reading BIG-IQ task %s/%s: %v", url, task.ID, err)
+		}
+		if err := json.Unmarshal(resp, task); err != nil {
+			return task, fmt.Errorf("error parsing BIG-IQ task %s/%s: %v", url, task.ID, err)
+		}
+	}
+}
+
+// bigiqMachineReference is the reference of a device by its machine ID, used by the device tasks.
+func bigiqMachineReference(machineID string) map[string]string {
+	return map[string]string{"link": bigiqMachineIdResolver + machineID}
+}
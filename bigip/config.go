@@ -11,6 +11,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync"
+	"time"
 
 	bigip "github.com/efellowsbg/go-bigip"
 )
@@ -68,3 +70,41 @@ func Client(config *bigip.Config) (*bigip.BigIP, error) {
 	return client, err
 
 }
+
+// providerConnection holds the connection settings of a configured provider, used by resources that
+// connect to another BIG-IP than the provider's.
+type providerConnection struct {
+	certVerifyDisable  bool
+	trustedCertificate string
+	loginReference     string
+	configOptions      *bigip.ConfigOptions
+}
+
+// providerConnections holds the connection settings of each configured provider, keyed by its BIG-IP
+// client, the meta passed to the resources.
+var providerConnections = struct {
+	sync.Mutex
+	connections map[*bigip.BigIP]providerConnection
+}{connections: make(map[*bigip.BigIP]providerConnection)}
+
+func setProviderConnection(client *bigip.BigIP, connection providerConnection) {
+	providerConnections.Lock()
+	defer providerConnections.Unlock()
+	providerConnections.connections[client] = connection
+}
+
+// providerConnectionOf returns the connection settings of the provider, or the provider defaults when
+// meta is not a configured provider.
+func providerConnectionOf(meta interface{}) providerConnection {
+	client, _ := meta.(*bigip.BigIP)
+	providerConnections.Lock()
+	defer providerConnections.Unlock()
+	if connection, ok := providerConnections.connections[client]; ok {
+		return connection
+	}
+	return providerConnection{
+		certVerifyDisable: true,
+		loginReference:    "tmos",
+		configOptions:     &bigip.ConfigOptions{APICallTimeout: 60 * time.Second, TokenTimeout: 1200 * time.Second, APICallRetries: 10},
+	}
+}
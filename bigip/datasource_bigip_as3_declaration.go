@@ -0,0 +1,628 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var as3ServiceClasses = []string{"Service_HTTP", "Service_HTTPS", "Service_TCP", "Service_UDP"}
+
+func dataSourceBigipAs3Declaration() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipAs3DeclarationRead,
+		Schema: map[string]*schema.Schema{
+			"schema_version": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "AS3 schema version of the declaration, e.g. 3.50.0",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^3\.[0-9]+\.[0-9]+$`), "must be an AS3 schema version such as 3.50.0"),
+			},
+			"declaration_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the declaration",
+			},
+			"label": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Label of the declaration",
+			},
+			"remark": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the declaration",
+			},
+			"tenant": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "Tenants of the declaration",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the tenant",
+						},
+						"remark": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Description of the tenant",
+						},
+						"default_route_domain": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Default route domain of the tenant",
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+						"application": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Description: "Applications of the tenant",
+							Elem:        as3DeclarationApplicationSchema(),
+						},
+					},
+				},
+			},
+			"as3_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Rendered AS3 declaration to use as as3_json of bigip_as3 or bigip_bigiq_as3",
+			},
+		},
+	}
+}
+
+func as3DeclarationApplicationSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the application",
+			},
+			"template": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "AS3 template of the application",
+				ValidateFunc: validation.StringInSlice([]string{"generic", "http", "https", "tcp", "udp", "l4", "shared"}, false),
+			},
+			"remark": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the application",
+			},
+			"service": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Virtual services of the application",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the service",
+						},
+						"class": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "AS3 class of the service, one of Service_HTTP, Service_HTTPS, Service_TCP or Service_UDP",
+							ValidateFunc: validation.StringInSlice(as3ServiceClasses, false),
+						},
+						"virtual_addresses": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Description: "Virtual addresses of the service",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"virtual_port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Virtual port of the service, defaults to the port of its class",
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+						"pool": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Pool of the service, the name of a pool of the application or the full path of a BIG-IP pool",
+						},
+						"irules": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "iRules of the service, names of iRules of the application or full paths of BIG-IP iRules",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"server_tls": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "TLS_Server of a Service_HTTPS, the name of a tls_server of the application or the full path of a BIG-IP client SSL profile",
+						},
+						"client_tls": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "TLS_Client of a Service_HTTPS, the name of a tls_client of the application or the full path of a BIG-IP server SSL profile",
+						},
+						"snat": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "SNAT of the service, auto, none, self or the full path of a BIG-IP SNAT pool",
+						},
+						"persistence_methods": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Persistence methods of the service, e.g. cookie or source-address",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"pool": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Pools of the application",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the pool",
+						},
+						"load_balancing_mode": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Load balancing mode of the pool, e.g. round-robin",
+						},
+						"monitors": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Monitors of the pool, names of AS3 built-in monitors or monitors of the application, or full paths of BIG-IP monitors",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"member": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Members of the pool",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"service_port": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										Description:  "Port of the members",
+										ValidateFunc: validation.IntBetween(0, 65535),
+									},
+									"server_addresses": {
+										Type:        schema.TypeList,
+										Optional:    true,
+										Description: "Static addresses of the members",
+										Elem:        &schema.Schema{Type: schema.TypeString},
+									},
+									"hostname": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "FQDN of the members, which sets addressDiscovery to fqdn",
+									},
+									"ratio": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Description:  "Ratio weight of the members",
+										ValidateFunc: validation.IntBetween(1, 65535),
+									},
+									"priority_group": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Description:  "Priority group of the members",
+										ValidateFunc: validation.IntBetween(0, 65535),
+									},
+									"connection_limit": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Description:  "Maximum concurrent connections of the members",
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+									"admin_state": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Description:  "Administrative state of the members, enable, disable or offline",
+										ValidateFunc: validation.StringInSlice([]string{"enable", "disable", "offline"}, false),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"monitor": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Monitors of the application",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the monitor",
+						},
+						"monitor_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Type of the monitor, e.g. http, https, tcp or icmp",
+							ValidateFunc: validation.StringInSlice([]string{"http", "https", "tcp", "tcp-half-open", "udp", "icmp", "dns", "external", "inband"}, false),
+						},
+						"interval": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Seconds between checks",
+						},
+						"timeout": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Seconds before a member is marked down",
+						},
+						"send": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Request sent by the monitor",
+						},
+						"receive": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Expected response",
+						},
+						"target_port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Port checked by the monitor, defaults to the port of the member",
+							ValidateFunc: validation.IntBetween(0, 65535),
+						},
+					},
+				},
+			},
+			"tls_server": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "TLS_Server objects, the client side TLS of HTTPS services",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the TLS_Server",
+						},
+						"certificates": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Description: "Certificates presented to clients, names of certificates of the application or full paths of BIG-IP certificates",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"tls_client": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "TLS_Client objects, the server side TLS of HTTPS services",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the TLS_Client",
+						},
+						"client_certificate": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Certificate presented to servers",
+						},
+						"trust_ca": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "CA bundle used to validate server certificates",
+						},
+					},
+				},
+			},
+			"certificate": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Certificate objects of the application",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the certificate",
+						},
+						"certificate": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "PEM encoded certificate",
+						},
+						"private_key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "PEM encoded private key",
+						},
+						"chain_ca": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "PEM encoded CA chain",
+						},
+					},
+				},
+			},
+			"irule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "iRule objects of the application",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the iRule",
+						},
+						"irule": {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "TCL body of the iRule",
+							ValidateDiagFunc: validateIRuleDiag,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBigipAs3DeclarationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	_ = meta // the declaration is rendered without a BIG-IP
+
+	adc := map[string]interface{}{
+		"class":         "ADC",
+		"schemaVersion": d.Get("schema_version").(string),
+	}
+	setAs3String(adc, "id", d.Get("declaration_id"))
+	setAs3String(adc, "label", d.Get("label"))
+	setAs3String(adc, "remark", d.Get("remark"))
+	for _, t := range d.Get("tenant").([]interface{}) {
+		tenant := t.(map[string]interface{})
+		name := tenant["name"].(string)
+		if _, ok := adc[name]; ok {
+			return diag.FromErr(fmt.Errorf("tenant (%s) is declared more than once", name))
+		}
+		rendered := map[string]interface{}{"class": "Tenant"}
+		setAs3String(rendered, "remark", tenant["remark"])
+		if routeDomain := tenant["default_route_domain"].(int); routeDomain != 0 {
+			rendered["defaultRouteDomain"] = routeDomain
+		}
+		for _, a := range tenant["application"].([]interface{}) {
+			application := a.(map[string]interface{})
+			appName := application["name"].(string)
+			if _, ok := rendered[appName]; ok {
+				return diag.FromErr(fmt.Errorf("application (%s) is declared more than once in tenant (%s)", appName, name))
+			}
+			app, err := renderAs3Application(application)
+			if err != nil {
+				return diag.FromErr(fmt.Errorf("error rendering application (%s) of tenant (%s): %v", appName, name, err))
+			}
+			rendered[appName] = app
+		}
+		adc[name] = rendered
+	}
+
+	declaration := map[string]interface{}{
+		"class":       "AS3",
+		"action":      "deploy",
+		"persist":     true,
+		"declaration": adc,
+	}
+	out, err := json.Marshal(declaration)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	as3Json := string(out)
+	log.Printf("[DEBUG] Rendered AS3 declaration: %s", as3Json)
+
+	if diags := validateDeclaration(as3DeclarationSchemas, as3Json, cty.GetAttrPath("tenant")); diags.HasError() {
+		return diags
+	}
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256(out)))
+	_ = d.Set("as3_json", as3Json)
+	return nil
+}
+
+func renderAs3Application(application map[string]interface{}) (map[string]interface{}, error) {
+	app := map[string]interface{}{"class": "Application"}
+	setAs3String(app, "template", application["template"])
+	setAs3String(app, "remark", application["remark"])
+
+	add := func(name string, object map[string]interface{}) error {
+		if _, ok := app[name]; ok {
+			return fmt.Errorf("object (%s) is declared more than once", name)
+		}
+		app[name] = object
+		return nil
+	}
+	for _, s := range application["service"].([]interface{}) {
+		service := s.(map[string]interface{})
+		rendered := map[string]interface{}{
+			"class":            service["class"].(string),
+			"virtualAddresses": service["virtual_addresses"],
+		}
+		if port := service["virtual_port"].(int); port != 0 {
+			rendered["virtualPort"] = port
+		}
+		setAs3Pointer(rendered, "pool", service["pool"])
+		setAs3Pointer(rendered, "serverTLS", service["server_tls"])
+		setAs3Pointer(rendered, "clientTLS", service["client_tls"])
+		if snat := service["snat"].(string); strings.HasPrefix(snat, "/") {
+			rendered["snat"] = map[string]interface{}{"bigip": snat}
+		} else {
+			setAs3String(rendered, "snat", snat)
+		}
+		setAs3Pointers(rendered, "iRules", service["irules"])
+		if methods := service["persistence_methods"].([]interface{}); len(methods) > 0 {
+			rendered["persistenceMethods"] = methods
+		}
+		if rendered["class"] != "Service_HTTPS" && (rendered["serverTLS"] != nil || rendered["clientTLS"] != nil) {
+			return nil, fmt.Errorf("server_tls and client_tls of service (%s) require the Service_HTTPS class", service["name"])
+		}
+		if err := add(service["name"].(string), rendered); err != nil {
+			return nil, err
+		}
+	}
+	monitors := make(map[string]bool)
+	for _, m := range application["monitor"].([]interface{}) {
+		monitors[m.(map[string]interface{})["name"].(string)] = true
+	}
+	for _, p := range application["pool"].([]interface{}) {
+		pool := p.(map[string]interface{})
+		rendered := map[string]interface{}{"class": "Pool"}
+		setAs3String(rendered, "loadBalancingMode", pool["load_balancing_mode"])
+		setAs3Monitors(rendered, pool["monitors"], monitors)
+		var members []interface{}
+		for _, m := range pool["member"].([]interface{}) {
+			members = append(members, renderAs3PoolMember(m.(map[string]interface{})))
+		}
+		if len(members) > 0 {
+			rendered["members"] = members
+		}
+		if err := add(pool["name"].(string), rendered); err != nil {
+			return nil, err
+		}
+	}
+	for _, m := range application["monitor"].([]interface{}) {
+		monitor := m.(map[string]interface{})
+		rendered := map[string]interface{}{
+			"class":       "Monitor",
+			"monitorType": monitor["monitor_type"].(string),
+		}
+		for key, attribute := range map[string]string{"interval": "interval", "timeout": "timeout", "targetPort": "target_port"} {
+			if value := monitor[attribute].(int); value != 0 {
+				rendered[key] = value
+			}
+		}
+		setAs3String(rendered, "send", monitor["send"])
+		setAs3String(rendered, "receive", monitor["receive"])
+		if err := add(monitor["name"].(string), rendered); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range application["tls_server"].([]interface{}) {
+		tls := t.(map[string]interface{})
+		var certificates []interface{}
+		for _, certificate := range tls["certificates"].([]interface{}) {
+			certificates = append(certificates, map[string]interface{}{"certificate": as3Pointer(certificate.(string))})
+		}
+		if err := add(tls["name"].(string), map[string]interface{}{"class": "TLS_Server", "certificates": certificates}); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range application["tls_client"].([]interface{}) {
+		tls := t.(map[string]interface{})
+		rendered := map[string]interface{}{"class": "TLS_Client"}
+		setAs3Pointer(rendered, "clientCertificate", tls["client_certificate"])
+		setAs3Pointer(rendered, "trustCA", tls["trust_ca"])
+		if err := add(tls["name"].(string), rendered); err != nil {
+			return nil, err
+		}
+	}
+	for _, c := range application["certificate"].([]interface{}) {
+		certificate := c.(map[string]interface{})
+		rendered := map[string]interface{}{
+			"class":       "Certificate",
+			"certificate": certificate["certificate"].(string),
+		}
+		setAs3String(rendered, "privateKey", certificate["private_key"])
+		setAs3String(rendered, "chainCA", certificate["chain_ca"])
+		if err := add(certificate["name"].(string), rendered); err != nil {
+			return nil, err
+		}
+	}
+	for _, i := range application["irule"].([]interface{}) {
+		irule := i.(map[string]interface{})
+		if err := add(irule["name"].(string), map[string]interface{}{"class": "iRule", "iRule": irule["irule"].(string)}); err != nil {
+			return nil, err
+		}
+	}
+	return app, nil
+}
+
+func renderAs3PoolMember(member map[string]interface{}) map[string]interface{} {
+	rendered := map[string]interface{}{"servicePort": member["service_port"].(int)}
+	if addresses := member["server_addresses"].([]interface{}); len(addresses) > 0 {
+		rendered["serverAddresses"] = addresses
+	}
+	if hostname := member["hostname"].(string); hostname != "" {
+		rendered["addressDiscovery"] = "fqdn"
+		rendered["hostname"] = hostname
+	}
+	for key, attribute := range map[string]string{"ratio": "ratio", "priorityGroup": "priority_group", "connectionLimit": "connection_limit"} {
+		if value := member[attribute].(int); value != 0 {
+			rendered[key] = value
+		}
+	}
+	setAs3String(rendered, "adminState", member["admin_state"])
+	return rendered
+}
+
+func setAs3String(object map[string]interface{}, key string, value interface{}) {
+	if s, ok := value.(string); ok && s != "" {
+		object[key] = s
+	}
+}
+
+// as3Pointer references an object of the same application by name, or a BIG-IP object by full path.
+func as3Pointer(value string) interface{} {
+	if strings.HasPrefix(value, "/") {
+		return map[string]interface{}{"bigip": value}
+	}
+	return value
+}
+
+func setAs3Pointer(object map[string]interface{}, key string, value interface{}) {
+	if s, ok := value.(string); ok && s != "" {
+		object[key] = as3Pointer(s)
+	}
+}
+
+// setAs3Monitors sets the monitors of a pool. Strings are built-in monitors in AS3, so the monitors of the
+// same application are referenced with a use pointer.
+func setAs3Monitors(object map[string]interface{}, values interface{}, applicationMonitors map[string]bool) {
+	var pointers []interface{}
+	for _, value := range values.([]interface{}) {
+		if name := value.(string); applicationMonitors[name] {
+			pointers = append(pointers, map[string]interface{}{"use": name})
+		} else {
+			pointers = append(pointers, as3Pointer(name))
+		}
+	}
+	if len(pointers) > 0 {
+		object["monitors"] = pointers
+	}
+}
+
+func setAs3Pointers(object map[string]interface{}, key string, values interface{}) {
+	var pointers []interface{}
+	for _, value := range values.([]interface{}) {
+		pointers = append(pointers, as3Pointer(value.(string)))
+	}
+	if len(pointers) > 0 {
+		object[key] = pointers
+	}
+}
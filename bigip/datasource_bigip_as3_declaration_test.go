@@ -0,0 +1,115 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceBigipAs3DeclarationRead(t *testing.T) {
+	raw := map[string]interface{}{
+		"schema_version": "3.50.0",
+		"tenant": []interface{}{
+			map[string]interface{}{
+				"name": "Sample_01",
+				"application": []interface{}{
+					map[string]interface{}{
+						"name":     "A1",
+						"template": "https",
+						"service": []interface{}{
+							map[string]interface{}{
+								"name":              "serviceMain",
+								"class":             "Service_HTTPS",
+								"virtual_addresses": []interface{}{"10.0.1.10"},
+								"pool":              "web_pool",
+								"server_tls":        "webtls",
+								"irules":            []interface{}{"/Common/_sys_https_redirect"},
+							},
+						},
+						"pool": []interface{}{
+							map[string]interface{}{
+								"name":     "web_pool",
+								"monitors": []interface{}{"http", "web_monitor"},
+								"member": []interface{}{
+									map[string]interface{}{
+										"service_port":     80,
+										"server_addresses": []interface{}{"192.0.1.10", "192.0.1.11"},
+									},
+								},
+							},
+						},
+						"monitor": []interface{}{
+							map[string]interface{}{
+								"name":         "web_monitor",
+								"monitor_type": "http",
+								"send":         "GET /health HTTP/1.0\r\n\r\n",
+							},
+						},
+						"tls_server": []interface{}{
+							map[string]interface{}{
+								"name":         "webtls",
+								"certificates": []interface{}{"/Common/default.crt"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, dataSourceBigipAs3Declaration().Schema, raw)
+	diags := dataSourceBigipAs3DeclarationRead(context.Background(), d, nil)
+	assert.False(t, diags.HasError(), "%v", diags)
+
+	var declaration map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(d.Get("as3_json").(string)), &declaration))
+	assert.Equal(t, "AS3", declaration["class"])
+	app := declaration["declaration"].(map[string]interface{})["Sample_01"].(map[string]interface{})["A1"].(map[string]interface{})
+	service := app["serviceMain"].(map[string]interface{})
+	assert.Equal(t, "web_pool", service["pool"])
+	assert.Equal(t, "webtls", service["serverTLS"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"bigip": "/Common/_sys_https_redirect"}}, service["iRules"])
+	pool := app["web_pool"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"servicePort": float64(80), "serverAddresses": []interface{}{"192.0.1.10", "192.0.1.11"}}}, pool["members"])
+	// built-in monitors are names, monitors of the application use pointers
+	assert.Equal(t, []interface{}{"http", map[string]interface{}{"use": "web_monitor"}}, pool["monitors"])
+	assert.Equal(t, "Monitor", app["web_monitor"].(map[string]interface{})["class"])
+	tls := app["webtls"].(map[string]interface{})
+	assert.Equal(t, []interface{}{map[string]interface{}{"certificate": map[string]interface{}{"bigip": "/Common/default.crt"}}}, tls["certificates"])
+	assert.NotEmpty(t, d.Id())
+
+	// Rendering is canonical, the same blocks always give the same declaration.
+	again := schema.TestResourceDataRaw(t, dataSourceBigipAs3Declaration().Schema, raw)
+	assert.False(t, dataSourceBigipAs3DeclarationRead(context.Background(), again, nil).HasError())
+	assert.Equal(t, d.Get("as3_json"), again.Get("as3_json"))
+	assert.Equal(t, d.Id(), again.Id())
+}
+
+func TestDataSourceBigipAs3DeclarationDuplicateObject(t *testing.T) {
+	raw := map[string]interface{}{
+		"schema_version": "3.50.0",
+		"tenant": []interface{}{
+			map[string]interface{}{
+				"name": "Sample_01",
+				"application": []interface{}{
+					map[string]interface{}{
+						"name":    "A1",
+						"pool":    []interface{}{map[string]interface{}{"name": "web"}},
+						"monitor": []interface{}{map[string]interface{}{"name": "web", "monitor_type": "http"}},
+					},
+				},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, dataSourceBigipAs3Declaration().Schema, raw)
+	diags := dataSourceBigipAs3DeclarationRead(context.Background(), d, nil)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "object (web) is declared more than once")
+}
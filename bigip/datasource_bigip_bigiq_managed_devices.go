@@ -0,0 +1,159 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBigiqManagedDevices() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigiqManagedDevicesRead,
+		Schema: map[string]*schema.Schema{
+			"bigiq_address": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"bigiq_address", "bigiq_user", "bigiq_password"},
+				Description:  "Address of the BIG-IQ",
+			},
+			"bigiq_user": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"bigiq_address", "bigiq_user", "bigiq_password"},
+				Sensitive:    true,
+				Description:  "User name of the BIG-IQ",
+			},
+			"bigiq_port": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Port of the BIG-IQ, if other than 443",
+			},
+			"bigiq_password": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"bigiq_address", "bigiq_user", "bigiq_password"},
+				Sensitive:    true,
+				Description:  "Password of the BIG-IQ",
+			},
+			"bigiq_token_auth": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Enable to use an external authentication source (LDAP, TACACS, etc)",
+				DefaultFunc: schema.EnvDefaultFunc("BIGIQ_TOKEN_AUTH", true),
+			},
+			"bigiq_login_ref": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Login reference for token authentication (see BIG-IQ REST docs for details)",
+				DefaultFunc: schema.EnvDefaultFunc("BIGIQ_LOGIN_REF", "local"),
+			},
+			"devices": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "BIG-IP devices managed by the BIG-IQ, sorted by address",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Hostname of the device",
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Address of the device, used as target of AS3 declarations",
+						},
+						"management_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Management address of the device",
+						},
+						"https_port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "HTTPS port of the device",
+						},
+						"uuid": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "UUID of the device on the BIG-IQ",
+						},
+						"machine_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Machine ID of the device",
+						},
+						"product": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Product of the device, e.g. BIG-IP",
+						},
+						"version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Software version of the device",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "State of the device on the BIG-IQ, e.g. ACTIVE",
+						},
+						"is_clustered": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the device is in a cluster",
+						},
+						"self_link": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Link to the device on the BIG-IQ",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBigiqManagedDevicesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bigiqRef, err := connectBigIq(d, meta)
+	if err != nil {
+		log.Printf("Connection to BIGIQ Failed with :%v", err)
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading devices managed by BIG-IQ %s", bigiqRef.Host)
+	devicesList, err := bigiqRef.GetManagedDevices()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading the devices managed by BIG-IQ: %v", err))
+	}
+	devices := devicesList.DevicesInfo
+	sort.Slice(devices, func(i, j int) bool { return devices[i].Address < devices[j].Address })
+	var deviceList []interface{}
+	for _, device := range devices {
+		deviceList = append(deviceList, map[string]interface{}{
+			"hostname":           device.Hostname,
+			"address":            device.Address,
+			"management_address": device.ManagementAddress,
+			"https_port":         device.HTTPSPort,
+			"uuid":               device.UUID,
+			"machine_id":         device.MachineID,
+			"product":            device.Product,
+			"version":            device.Version,
+			"state":              device.State,
+			"is_clustered":       device.IsClustered,
+			"self_link":          device.SelfLink,
+		})
+	}
+	_ = d.Set("devices", deviceList)
+	d.SetId(bigiqRef.Host)
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"log"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBigipDeviceInfo() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipDeviceInfoRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "TMOS version of the BIG-IP, e.g. 17.1.0",
+			},
+			"build": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Build of the TMOS version",
+			},
+			"platform_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Platform ID of the BIG-IP, Z100 for Virtual Edition",
+			},
+			"marketing_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the platform",
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hostname of the BIG-IP",
+			},
+			"base_mac": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Base MAC address of the BIG-IP",
+			},
+			"ha_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Failover state of the BIG-IP, e.g. active or standby",
+			},
+			"licensed_modules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Modules enabled by the license",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"provisioned_modules": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Provisioning level of the provisioned modules, by module name",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"available_modules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Modules the platform can provision",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceBigipDeviceInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading the device info of %s", client.Host)
+
+	info, err := getBigipDeviceInfo(client)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(client.Host)
+	_ = d.Set("version", info.version)
+	_ = d.Set("build", info.build)
+	_ = d.Set("platform_id", info.platformID)
+	_ = d.Set("marketing_name", info.marketingName)
+	_ = d.Set("hostname", info.hostname)
+	_ = d.Set("base_mac", info.baseMac)
+	_ = d.Set("ha_state", info.haState)
+	_ = d.Set("licensed_modules", info.licensedModules)
+	_ = d.Set("provisioned_modules", info.provisionedModules)
+	_ = d.Set("available_modules", info.availableModules)
+	return nil
+}
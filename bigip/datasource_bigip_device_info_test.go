@@ -0,0 +1,87 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// handleBigipDeviceInfo serves the version and the devices of a BIG-IP of platform platformID.
+func handleBigipDeviceInfo(version, platformID string) {
+	mux.HandleFunc("/mgmt/tm/cli/version", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"entries": {"https://localhost/mgmt/tm/cli/version/0": {"nestedStats": {"entries": {
+			"active": {"description": "%s"}, "latest": {"description": "%s"}}}}}}`, version, version)
+	})
+	mux.HandleFunc("/mgmt/tm/cm/device", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"items": [
+			{"name": "bigip2.example.com", "hostname": "bigip2.example.com", "selfDevice": "false", "failoverState": "active"},
+			{"name": "bigip1.example.com", "hostname": "bigip1.example.com", "selfDevice": "true", "failoverState": "standby",
+			 "baseMac": "52:54:00:12:34:56", "build": "0.0.6", "version": "%s", "platformId": "%s", "marketingName": "BIG-IP Virtual Edition",
+			 "activeModules": ["Best Bundle, VE-1G|KEY1|Rate Shaping|Anti-Virus Checks", "APM, Base, VE|KEY2"]}]}`, version, platformID)
+	})
+}
+
+func TestDataSourceBigipDeviceInfoRead(t *testing.T) {
+	setup()
+	defer teardown()
+	handleBigipDeviceInfo("17.1.0", "Z100")
+	handleBigipProvisioning(t, map[string]string{"afm": "none", "asm": "nominal", "gtm": "none", "ltm": "nominal"}, "")
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceBigipDeviceInfo().Schema, map[string]interface{}{})
+	diags := dataSourceBigipDeviceInfoRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "17.1.0", d.Get("version"))
+	assert.Equal(t, "Z100", d.Get("platform_id"))
+	assert.Equal(t, "bigip1.example.com", d.Get("hostname"))
+	assert.Equal(t, "52:54:00:12:34:56", d.Get("base_mac"))
+	assert.Equal(t, "standby", d.Get("ha_state"))
+	assert.Equal(t, []interface{}{"APM, Base, VE", "Best Bundle, VE-1G"}, d.Get("licensed_modules"))
+	assert.Equal(t, map[string]interface{}{"asm": "nominal", "ltm": "nominal"}, d.Get("provisioned_modules"))
+	assert.Equal(t, 4, d.Get("available_modules.#"))
+}
+
+func TestCheckBigipCapability(t *testing.T) {
+	client := &bigip.BigIP{Host: "bigip.example.com"}
+	info := &bigipDeviceInfo{
+		version:            "14.0.1",
+		platformID:         "Z100",
+		marketingName:      "BIG-IP Virtual Edition",
+		provisionedModules: map[string]string{"ltm": "nominal"},
+		availableModules:   []string{"afm", "asm", "ltm"},
+	}
+
+	assert.NoError(t, checkBigipCapability(client, info, "bigip_ipsec_policy", bigipCapability{modules: []string{"ltm"}}))
+	assert.EqualError(t, checkBigipCapability(client, info, "bigip_vcmp_guest", bigipCapability{modules: []string{"vcmp"}, hardware: true}),
+		"bigip_vcmp_guest is not supported on BIG-IP Virtual Edition: bigip.example.com is platform Z100 (BIG-IP Virtual Edition)")
+	assert.EqualError(t, checkBigipCapability(client, info, "bigip_ltm_profile_bot_defense", bigipCapability{modules: []string{"asm", "dos"}, minVersion: "14.1"}),
+		"bigip_ltm_profile_bot_defense requires BIG-IP 14.1 or later: bigip.example.com runs 14.0.1")
+	// asm is available, and may be provisioned by the same apply
+	assert.NoError(t, checkBigipCapability(client, info, "bigip_waf_policy", bigipCapability{modules: []string{"asm"}}))
+	assert.EqualError(t, checkBigipCapability(client, info, "bigip_pem_policy", bigipCapability{modules: []string{"pem"}}),
+		"bigip_pem_policy requires module pem, which platform Z100 (BIG-IP Virtual Edition) of bigip.example.com does not support")
+
+	info.provisionedModules["asm"] = "nominal"
+	assert.NoError(t, checkBigipCapability(client, info, "bigip_waf_policy", bigipCapability{modules: []string{"asm"}}))
+	info.provisionedModules["ltm"], info.availableModules = "none", []string{"asm"}
+	assert.EqualError(t, checkBigipCapability(client, info, "bigip_ipsec_policy", bigipCapability{modules: []string{"ltm"}}),
+		"bigip_ipsec_policy requires module ltm, which platform Z100 (BIG-IP Virtual Edition) of bigip.example.com does not support")
+
+	assert.True(t, versionAtLeast("17.1.0.1", "14.1"))
+	assert.True(t, versionAtLeast("14.1", "14.1"))
+	assert.False(t, versionAtLeast("13.1.5", "14.1"))
+}
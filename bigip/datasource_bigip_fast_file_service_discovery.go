@@ -0,0 +1,105 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
+)
+
+func dataSourceBigipFastFileServiceDiscovery() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipFastFileServiceDiscoveryRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the JSON or YAML file listing the nodes",
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Format of the file, json or yaml. Defaults from the file extension",
+				ValidateFunc: validation.StringInSlice([]string{"json", "yaml"}, false),
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Port of the nodes listed without a port",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"node": serviceDiscoveryNodesSchema(),
+		},
+	}
+}
+
+func dataSourceBigipFastFileServiceDiscoveryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	path := d.Get("path").(string)
+	log.Printf("[INFO] Reading service discovery nodes from %s", path)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading service discovery file: %v", err))
+	}
+	format := d.Get("format").(string)
+	if format == "" {
+		format = "json"
+		if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+			format = "yaml"
+		}
+	}
+	nodes, err := parseServiceDiscoveryFile(content, format, d.Get("port").(int))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error parsing service discovery file %s: %v", path, err))
+	}
+	d.SetId(path)
+	_ = d.Set("node", flattenServiceDiscoveryNodes(nodes))
+	return nil
+}
+
+// parseServiceDiscoveryFile parses the nodes of a file, either a list of nodes or an object with a nodes
+// list. Nodes without an id are identified by their address and port.
+func parseServiceDiscoveryFile(content []byte, format string, port int) ([]serviceDiscoveryNode, error) {
+	var file struct {
+		Nodes []serviceDiscoveryNode `json:"nodes" yaml:"nodes"`
+	}
+	unmarshal := json.Unmarshal
+	if format == "yaml" {
+		unmarshal = yaml.Unmarshal
+	}
+	if err := unmarshal(content, &file.Nodes); err != nil {
+		if err := unmarshal(content, &file); err != nil {
+			return nil, err
+		}
+	}
+	for i := range file.Nodes {
+		node := &file.Nodes[i]
+		if node.Port == 0 {
+			node.Port = port
+		}
+		if node.Port == 0 {
+			return nil, fmt.Errorf("node %s has no port, and the data source sets no port", node.IP)
+		}
+		if node.ID == "" {
+			node.ID = net.JoinHostPort(node.IP, strconv.Itoa(node.Port))
+		}
+	}
+	if err := validateServiceDiscoveryNodes(file.Nodes); err != nil {
+		return nil, err
+	}
+	return file.Nodes, nil
+}
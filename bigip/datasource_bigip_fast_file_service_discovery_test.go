@@ -0,0 +1,47 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseServiceDiscoveryFile(t *testing.T) {
+	nodes, err := parseServiceDiscoveryFile([]byte(`[{"id": "web1", "ip": "10.1.1.1", "port": 80}, {"ip": "10.1.1.2"}]`), "json", 8080)
+	assert.NoError(t, err)
+	assert.Equal(t, []serviceDiscoveryNode{{ID: "web1", IP: "10.1.1.1", Port: 80}, {ID: "10.1.1.2:8080", IP: "10.1.1.2", Port: 8080}}, nodes)
+
+	nodes, err = parseServiceDiscoveryFile([]byte("nodes:\n- id: web1\n  ip: 10.1.1.1\n  port: 80\n"), "yaml", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []serviceDiscoveryNode{{ID: "web1", IP: "10.1.1.1", Port: 80}}, nodes)
+
+	_, err = parseServiceDiscoveryFile([]byte(`[{"ip": "10.1.1.1"}]`), "json", 0)
+	assert.ErrorContains(t, err, "has no port")
+	_, err = parseServiceDiscoveryFile([]byte(`[{"id": "web1", "ip": "web.example.com", "port": 80}]`), "json", 0)
+	assert.ErrorContains(t, err, "invalid IP address")
+	_, err = parseServiceDiscoveryFile([]byte(`[{"id": "web1", "ip": "10.1.1.1", "port": 80}, {"id": "web1", "ip": "10.1.1.2", "port": 80}]`), "json", 0)
+	assert.ErrorContains(t, err, "duplicate node id web1")
+}
+
+func TestDataSourceBigipFastFileServiceDiscoveryRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nodes.yml")
+	assert.NoError(t, os.WriteFile(path, []byte("- {id: web2, ip: 10.1.1.2}\n- {id: web1, ip: 10.1.1.1}\n"), 0600))
+
+	d := schema.TestResourceDataRaw(t, dataSourceBigipFastFileServiceDiscovery().Schema, map[string]interface{}{
+		"path": path,
+		"port": 443,
+	})
+	diags := dataSourceBigipFastFileServiceDiscoveryRead(context.Background(), d, nil)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "web1", d.Get("node.0.id"))
+	assert.Equal(t, 443, d.Get("node.1.port"))
+}
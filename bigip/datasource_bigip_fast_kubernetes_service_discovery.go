@@ -0,0 +1,518 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
+)
+
+func dataSourceBigipFastKubernetesServiceDiscovery() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipFastKubernetesServiceDiscoveryRead,
+		Schema: map[string]*schema.Schema{
+			"kubeconfig_path": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Path of the kubeconfig file. Defaults to the first file of KUBECONFIG, or ~/.kube/config",
+				ConflictsWith: []string{"kubeconfig"},
+			},
+			"kubeconfig": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "Content of the kubeconfig file",
+				ConflictsWith: []string{"kubeconfig_path"},
+			},
+			"context": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Context of the kubeconfig to use. Defaults to its current context",
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Namespace of the service. Defaults to the namespace of the context, or default",
+			},
+			"service": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Name of the service whose endpoints are discovered",
+				ExactlyOneOf: []string{"service", "label_selector"},
+			},
+			"label_selector": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Label selector of the EndpointSlices, or Endpoints, to discover",
+			},
+			"port_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the endpoint port. Required when the endpoints have several ports",
+			},
+			"ready_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Only discover ready endpoints",
+			},
+			"source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "endpointslices",
+				Description:  "Kubernetes API discovered: endpointslices, or endpoints for clusters older than 1.21",
+				ValidateFunc: validation.StringInSlice([]string{"endpointslices", "endpoints"}, false),
+			},
+			"address_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "IPv4",
+				Description:  "Address type of the EndpointSlices to discover, IPv4 or IPv6",
+				ValidateFunc: validation.StringInSlice([]string{"IPv4", "IPv6"}, false),
+			},
+			"node": serviceDiscoveryNodesSchema(),
+		},
+	}
+}
+
+func dataSourceBigipFastKubernetesServiceDiscoveryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := []byte(d.Get("kubeconfig").(string))
+	dir := ""
+	if len(config) == 0 {
+		path := kubeconfigPath(d.Get("kubeconfig_path").(string))
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error reading kubeconfig: %v", err))
+		}
+		config, dir = content, filepath.Dir(path)
+	}
+	client, namespace, err := newKubernetesClient(config, dir, d.Get("context").(string))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error loading kubeconfig: %v", err))
+	}
+	if v, ok := d.GetOk("namespace"); ok {
+		namespace = v.(string)
+	}
+	query := kubernetesEndpointsQuery{
+		namespace:   namespace,
+		service:     d.Get("service").(string),
+		selector:    d.Get("label_selector").(string),
+		portName:    d.Get("port_name").(string),
+		readyOnly:   d.Get("ready_only").(bool),
+		addressType: d.Get("address_type").(string),
+	}
+	log.Printf("[INFO] Discovering Kubernetes %s of %s in %s", d.Get("source"), query.target(), namespace)
+	var nodes []serviceDiscoveryNode
+	if d.Get("source").(string) == "endpoints" {
+		nodes, err = client.discoverEndpoints(ctx, query)
+	} else {
+		nodes, err = client.discoverEndpointSlices(ctx, query)
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error discovering %s in namespace %s: %v", query.target(), namespace, err))
+	}
+	nodes = uniqueKubernetesNodes(nodes)
+	if err := validateServiceDiscoveryNodes(nodes); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s/%s/%s", client.server, namespace, query.target()))
+	_ = d.Set("node", flattenServiceDiscoveryNodes(nodes))
+	return nil
+}
+
+// kubeconfigPath returns the path of the kubeconfig, defaulting like kubectl, with ~ expanded.
+func kubeconfigPath(path string) string {
+	home, _ := os.UserHomeDir()
+	if path == "" {
+		if env := os.Getenv("KUBECONFIG"); env != "" {
+			path = filepath.SplitList(env)[0]
+		} else {
+			path = filepath.Join(home, ".kube", "config")
+		}
+	}
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		path = filepath.Join(home, path[1:])
+	}
+	return path
+}
+
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster   string `yaml:"cluster"`
+			User      string `yaml:"user"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string      `yaml:"token"`
+			TokenFile             string      `yaml:"tokenFile"`
+			ClientCertificate     string      `yaml:"client-certificate"`
+			ClientCertificateData string      `yaml:"client-certificate-data"`
+			ClientKey             string      `yaml:"client-key"`
+			ClientKeyData         string      `yaml:"client-key-data"`
+			Username              string      `yaml:"username"`
+			Password              string      `yaml:"password"`
+			Exec                  interface{} `yaml:"exec"`
+			AuthProvider          interface{} `yaml:"auth-provider"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// kubernetesClient reads the Kubernetes API with the cluster and credentials of a kubeconfig context.
+type kubernetesClient struct {
+	server   string
+	token    string
+	username string
+	password string
+	http     *http.Client
+}
+
+// newKubernetesClient returns the client of a context of a kubeconfig, and the namespace of the context.
+// Relative file paths of the kubeconfig are relative to dir. Exec and auth provider credential plugins
+// are not supported.
+func newKubernetesClient(config []byte, dir, contextName string) (*kubernetesClient, string, error) {
+	var kc kubeconfig
+	if err := yaml.Unmarshal(config, &kc); err != nil {
+		return nil, "", err
+	}
+	if contextName == "" {
+		contextName = kc.CurrentContext
+	}
+	if contextName == "" {
+		return nil, "", fmt.Errorf("no context set and no current-context")
+	}
+	var clusterName, userName, namespace string
+	found := false
+	for _, c := range kc.Contexts {
+		if c.Name == contextName {
+			clusterName, userName, namespace, found = c.Context.Cluster, c.Context.User, c.Context.Namespace, true
+		}
+	}
+	if !found {
+		return nil, "", fmt.Errorf("context %s not found", contextName)
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	readFile := func(path string) ([]byte, error) {
+		if !filepath.IsAbs(path) && dir != "" {
+			path = filepath.Join(dir, path)
+		}
+		return os.ReadFile(path)
+	}
+	dataOrFile := func(data, path string) ([]byte, error) {
+		if data != "" {
+			return base64.StdEncoding.DecodeString(data)
+		}
+		if path != "" {
+			return readFile(path)
+		}
+		return nil, nil
+	}
+
+	client := &kubernetesClient{}
+	tlsConfig := &tls.Config{}
+	found = false
+	for _, c := range kc.Clusters {
+		if c.Name != clusterName {
+			continue
+		}
+		found = true
+		client.server = strings.TrimSuffix(c.Cluster.Server, "/")
+		tlsConfig.InsecureSkipVerify = c.Cluster.InsecureSkipTLSVerify
+		ca, err := dataOrFile(c.Cluster.CertificateAuthorityData, c.Cluster.CertificateAuthority)
+		if err != nil {
+			return nil, "", fmt.Errorf("error reading certificate authority of cluster %s: %v", clusterName, err)
+		}
+		if ca != nil {
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(ca) {
+				return nil, "", fmt.Errorf("invalid certificate authority of cluster %s", clusterName)
+			}
+			tlsConfig.RootCAs = pool
+		}
+	}
+	if !found || client.server == "" {
+		return nil, "", fmt.Errorf("cluster %s of context %s not found", clusterName, contextName)
+	}
+	for _, u := range kc.Users {
+		if u.Name != userName {
+			continue
+		}
+		if u.User.Exec != nil || u.User.AuthProvider != nil {
+			return nil, "", fmt.Errorf("user %s uses a credential plugin, which is not supported: use a token or a client certificate", userName)
+		}
+		client.token, client.username, client.password = u.User.Token, u.User.Username, u.User.Password
+		if client.token == "" && u.User.TokenFile != "" {
+			token, err := readFile(u.User.TokenFile)
+			if err != nil {
+				return nil, "", fmt.Errorf("error reading token of user %s: %v", userName, err)
+			}
+			client.token = strings.TrimSpace(string(token))
+		}
+		cert, err := dataOrFile(u.User.ClientCertificateData, u.User.ClientCertificate)
+		if err != nil {
+			return nil, "", fmt.Errorf("error reading client certificate of user %s: %v", userName, err)
+		}
+		key, err := dataOrFile(u.User.ClientKeyData, u.User.ClientKey)
+		if err != nil {
+			return nil, "", fmt.Errorf("error reading client key of user %s: %v", userName, err)
+		}
+		if cert != nil && key != nil {
+			pair, err := tls.X509KeyPair(cert, key)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid client certificate of user %s: %v", userName, err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{pair}
+		}
+	}
+	client.http = &http.Client{
+		Timeout:   30 * time.Second,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig, Proxy: http.ProxyFromEnvironment},
+	}
+	return client, namespace, nil
+}
+
+func (c *kubernetesClient) get(ctx context.Context, path string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.server+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	} else if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var status struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &status) == nil && status.Message != "" {
+			return fmt.Errorf("HTTP %d: %s", resp.StatusCode, status.Message)
+		}
+		return fmt.Errorf("HTTP %d: %s", resp.StatusCode, body)
+	}
+	return json.Unmarshal(body, out)
+}
+
+// kubernetesEndpointsQuery selects the endpoints of a service, or of a label selector, and their port.
+type kubernetesEndpointsQuery struct {
+	namespace   string
+	service     string
+	selector    string
+	portName    string
+	readyOnly   bool
+	addressType string
+}
+
+func (q kubernetesEndpointsQuery) target() string {
+	if q.service != "" {
+		return "service " + q.service
+	}
+	return "selector " + q.selector
+}
+
+type kubernetesEndpointPort struct {
+	Name string `json:"name"`
+	Port int    `json:"port"`
+}
+
+type kubernetesTargetRef struct {
+	Name string `json:"name"`
+}
+
+// port returns the port of the query among the ports of endpoints.
+func (q kubernetesEndpointsQuery) port(ports []kubernetesEndpointPort) (int, error) {
+	var names []string
+	for _, p := range ports {
+		if q.portName != "" && p.Name == q.portName {
+			return p.Port, nil
+		}
+		names = append(names, p.Name)
+	}
+	sort.Strings(names)
+	if q.portName != "" {
+		return 0, fmt.Errorf("no port %s, the ports are %s", q.portName, strings.Join(names, ", "))
+	}
+	if len(ports) != 1 {
+		return 0, fmt.Errorf("the endpoints have %d ports (%s): set port_name", len(ports), strings.Join(names, ", "))
+	}
+	return ports[0].Port, nil
+}
+
+func kubernetesNodeID(ref *kubernetesTargetRef, ip string) string {
+	if ref != nil && ref.Name != "" {
+		return ref.Name
+	}
+	return ip
+}
+
+// uniqueKubernetesNodes removes the endpoints listed more than once, e.g. in several EndpointSlices, and
+// makes the ids of the pods found on several ports, e.g. by a selector covering several services, unique
+// by adding the port.
+func uniqueKubernetesNodes(nodes []serviceDiscoveryNode) []serviceDiscoveryNode {
+	var unique []serviceDiscoveryNode
+	seen := make(map[string]bool)
+	ports := make(map[string]map[int]bool)
+	for _, node := range nodes {
+		endpoint := net.JoinHostPort(node.IP, strconv.Itoa(node.Port))
+		if seen[endpoint] {
+			continue
+		}
+		seen[endpoint] = true
+		unique = append(unique, node)
+		if ports[node.ID] == nil {
+			ports[node.ID] = make(map[int]bool)
+		}
+		ports[node.ID][node.Port] = true
+	}
+	for i, node := range unique {
+		if len(ports[node.ID]) > 1 {
+			unique[i].ID = fmt.Sprintf("%s:%d", node.ID, node.Port)
+		}
+	}
+	return unique
+}
+
+// discoverEndpointSlices returns the nodes of the EndpointSlices of the query.
+func (c *kubernetesClient) discoverEndpointSlices(ctx context.Context, q kubernetesEndpointsQuery) ([]serviceDiscoveryNode, error) {
+	selector := q.selector
+	if q.service != "" {
+		selector = "kubernetes.io/service-name=" + q.service
+	}
+	var slices struct {
+		Items []struct {
+			AddressType string `json:"addressType"`
+			Endpoints   []struct {
+				Addresses  []string `json:"addresses"`
+				Conditions struct {
+					Ready *bool `json:"ready"`
+				} `json:"conditions"`
+				TargetRef *kubernetesTargetRef `json:"targetRef"`
+			} `json:"endpoints"`
+			Ports []kubernetesEndpointPort `json:"ports"`
+		} `json:"items"`
+	}
+	path := fmt.Sprintf("/apis/discovery.k8s.io/v1/namespaces/%s/endpointslices?labelSelector=%s", url.PathEscape(q.namespace), url.QueryEscape(selector))
+	if err := c.get(ctx, path, &slices); err != nil {
+		return nil, err
+	}
+	var nodes []serviceDiscoveryNode
+	for _, slice := range slices.Items {
+		if slice.AddressType != q.addressType || len(slice.Endpoints) == 0 {
+			continue
+		}
+		port, err := q.port(slice.Ports)
+		if err != nil {
+			return nil, err
+		}
+		for _, endpoint := range slice.Endpoints {
+			// a nil ready condition means ready
+			ready := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+			if len(endpoint.Addresses) == 0 || (q.readyOnly && !ready) {
+				continue
+			}
+			ip := endpoint.Addresses[0]
+			nodes = append(nodes, serviceDiscoveryNode{ID: kubernetesNodeID(endpoint.TargetRef, ip), IP: ip, Port: port})
+		}
+	}
+	return nodes, nil
+}
+
+// discoverEndpoints returns the nodes of the Endpoints of the query.
+func (c *kubernetesClient) discoverEndpoints(ctx context.Context, q kubernetesEndpointsQuery) ([]serviceDiscoveryNode, error) {
+	type endpointAddress struct {
+		IP        string               `json:"ip"`
+		TargetRef *kubernetesTargetRef `json:"targetRef"`
+	}
+	type endpoints struct {
+		Subsets []struct {
+			Addresses         []endpointAddress        `json:"addresses"`
+			NotReadyAddresses []endpointAddress        `json:"notReadyAddresses"`
+			Ports             []kubernetesEndpointPort `json:"ports"`
+		} `json:"subsets"`
+	}
+	var items []endpoints
+	if q.service != "" {
+		var ep endpoints
+		if err := c.get(ctx, fmt.Sprintf("/api/v1/namespaces/%s/endpoints/%s", url.PathEscape(q.namespace), url.PathEscape(q.service)), &ep); err != nil {
+			return nil, err
+		}
+		items = append(items, ep)
+	} else {
+		var list struct {
+			Items []endpoints `json:"items"`
+		}
+		if err := c.get(ctx, fmt.Sprintf("/api/v1/namespaces/%s/endpoints?labelSelector=%s", url.PathEscape(q.namespace), url.QueryEscape(q.selector)), &list); err != nil {
+			return nil, err
+		}
+		items = list.Items
+	}
+	var nodes []serviceDiscoveryNode
+	for _, ep := range items {
+		for _, subset := range ep.Subsets {
+			addresses := subset.Addresses
+			if !q.readyOnly {
+				addresses = append(addresses, subset.NotReadyAddresses...)
+			}
+			if len(addresses) == 0 {
+				continue
+			}
+			port, err := q.port(subset.Ports)
+			if err != nil {
+				return nil, err
+			}
+			for _, address := range addresses {
+				nodes = append(nodes, serviceDiscoveryNode{ID: kubernetesNodeID(address.TargetRef, address.IP), IP: address.IP, Port: port})
+			}
+		}
+	}
+	return nodes, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKubeconfig = `
+apiVersion: v1
+kind: Config
+current-context: dev
+clusters:
+- name: dev-cluster
+  cluster:
+    server: %s
+contexts:
+- name: dev
+  context:
+    cluster: dev-cluster
+    user: dev-user
+    namespace: web
+users:
+- name: dev-user
+  user:
+    token: secret-token
+`
+
+func TestDataSourceBigipFastKubernetesServiceDiscoveryRead(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/apis/discovery.k8s.io/v1/namespaces/web/endpointslices", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret-token", r.Header.Get("Authorization"))
+		assert.Equal(t, "kubernetes.io/service-name=frontend", r.URL.Query().Get("labelSelector"))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"items": [
+			{"addressType": "IPv4", "ports": [{"name": "http", "port": 8080}, {"name": "metrics", "port": 9090}], "endpoints": [
+				{"addresses": ["10.244.1.5"], "conditions": {"ready": true}, "targetRef": {"kind": "Pod", "name": "frontend-a"}},
+				{"addresses": ["10.244.2.7"], "conditions": {"ready": false}, "targetRef": {"kind": "Pod", "name": "frontend-b"}},
+				{"addresses": ["10.244.3.9"], "conditions": {}}
+			]},
+			{"addressType": "IPv6", "ports": [{"name": "http", "port": 8080}], "endpoints": [
+				{"addresses": ["fd00::5"], "conditions": {"ready": true}, "targetRef": {"kind": "Pod", "name": "frontend-a"}}
+			]}
+		]}`)
+	})
+	mux.HandleFunc("/api/v1/namespaces/web/endpoints/frontend", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"subsets": [{"addresses": [{"ip": "10.244.1.5", "targetRef": {"name": "frontend-a"}}],
+			"notReadyAddresses": [{"ip": "10.244.2.7", "targetRef": {"name": "frontend-b"}}], "ports": [{"name": "http", "port": 8080}]}]}`)
+	})
+	kubeconfig := fmt.Sprintf(testKubeconfig, server.URL)
+
+	d := schema.TestResourceDataRaw(t, dataSourceBigipFastKubernetesServiceDiscovery().Schema, map[string]interface{}{
+		"kubeconfig": kubeconfig,
+		"service":    "frontend",
+		"port_name":  "http",
+	})
+	diags := dataSourceBigipFastKubernetesServiceDiscoveryRead(context.Background(), d, nil)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": "10.244.3.9", "ip": "10.244.3.9", "port": 8080},
+		map[string]interface{}{"id": "frontend-a", "ip": "10.244.1.5", "port": 8080},
+	}, d.Get("node"), "only ready IPv4 endpoints")
+
+	d = schema.TestResourceDataRaw(t, dataSourceBigipFastKubernetesServiceDiscovery().Schema, map[string]interface{}{
+		"kubeconfig": kubeconfig,
+		"service":    "frontend",
+	})
+	diags = dataSourceBigipFastKubernetesServiceDiscoveryRead(context.Background(), d, nil)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "the endpoints have 2 ports (http, metrics): set port_name")
+
+	d = schema.TestResourceDataRaw(t, dataSourceBigipFastKubernetesServiceDiscovery().Schema, map[string]interface{}{
+		"kubeconfig": kubeconfig,
+		"service":    "frontend",
+		"source":     "endpoints",
+		"ready_only": false,
+	})
+	diags = dataSourceBigipFastKubernetesServiceDiscoveryRead(context.Background(), d, nil)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 2, d.Get("node.#"))
+	assert.Equal(t, "frontend-b", d.Get("node.1.id"))
+}
+
+func TestDataSourceBigipFastKubernetesServiceDiscoveryDuplicates(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/apis/discovery.k8s.io/v1/namespaces/web/endpointslices", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tier=web", r.URL.Query().Get("labelSelector"))
+		w.Header().Set("Content-Type", "application/json")
+		// frontend-a is listed in two slices of the frontend service, and exposed on another port by the admin service
+		_, _ = fmt.Fprint(w, `{"items": [
+			{"addressType": "IPv4", "ports": [{"name": "http", "port": 8080}], "endpoints": [
+				{"addresses": ["10.244.1.5"], "targetRef": {"kind": "Pod", "name": "frontend-a"}},
+				{"addresses": ["10.244.2.7"], "targetRef": {"kind": "Pod", "name": "frontend-b"}}
+			]},
+			{"addressType": "IPv4", "ports": [{"name": "http", "port": 8080}], "endpoints": [
+				{"addresses": ["10.244.1.5"], "targetRef": {"kind": "Pod", "name": "frontend-a"}}
+			]},
+			{"addressType": "IPv4", "ports": [{"name": "http", "port": 9000}], "endpoints": [
+				{"addresses": ["10.244.1.5"], "targetRef": {"kind": "Pod", "name": "frontend-a"}}
+			]}
+		]}`)
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceBigipFastKubernetesServiceDiscovery().Schema, map[string]interface{}{
+		"kubeconfig":     fmt.Sprintf(testKubeconfig, server.URL),
+		"label_selector": "tier=web",
+		"port_name":      "http",
+	})
+	diags := dataSourceBigipFastKubernetesServiceDiscoveryRead(context.Background(), d, nil)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"id": "frontend-a:8080", "ip": "10.244.1.5", "port": 8080},
+		map[string]interface{}{"id": "frontend-a:9000", "ip": "10.244.1.5", "port": 9000},
+		map[string]interface{}{"id": "frontend-b", "ip": "10.244.2.7", "port": 8080},
+	}, d.Get("node"))
+}
+
+func TestNewKubernetesClient(t *testing.T) {
+	home, _ := os.UserHomeDir()
+	assert.Equal(t, filepath.Join(home, ".kube", "prod"), kubeconfigPath("~/.kube/prod"))
+	assert.Equal(t, "/etc/kubeconfig", kubeconfigPath("/etc/kubeconfig"))
+
+	_, _, err := newKubernetesClient([]byte(fmt.Sprintf(testKubeconfig, "https://k8s.example.com")), "", "prod")
+	assert.ErrorContains(t, err, "context prod not found")
+
+	client, namespace, err := newKubernetesClient([]byte(fmt.Sprintf(testKubeconfig, "https://k8s.example.com/")), "", "")
+	assert.NoError(t, err)
+	assert.Equal(t, "web", namespace)
+	assert.Equal(t, "https://k8s.example.com", client.server)
+	assert.Equal(t, "secret-token", client.token)
+
+	_, _, err = newKubernetesClient([]byte(`
+current-context: eks
+clusters: [{name: eks, cluster: {server: "https://eks.example.com"}}]
+contexts: [{name: eks, context: {cluster: eks, user: eks}}]
+users: [{name: eks, user: {exec: {command: aws}}}]
+`), "", "")
+	assert.ErrorContains(t, err, "credential plugin, which is not supported")
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"regexp"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceBigipFastRender() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipFastRenderRead,
+		Schema: map[string]*schema.Schema{
+			"template": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the FAST template, as template set/template",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^/]+/[^/]+$`), "must be template set/template, e.g. examples/simple_http"),
+			},
+			"fast_json": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Parameters of the application, as JSON",
+				ValidateFunc: validation.StringIsJSON,
+			},
+			"rendered_as3": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "AS3 declaration rendered by FAST",
+			},
+		},
+	}
+}
+
+func dataSourceBigipFastRenderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	template := d.Get("template").(string)
+	log.Printf("[INFO] Rendering FAST template %s", template)
+	rendered, err := renderFastApplication(client, template, d.Get("fast_json").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%x", sha256.Sum256([]byte(template+rendered))))
+	_ = d.Set("rendered_as3", rendered)
+	return nil
+}
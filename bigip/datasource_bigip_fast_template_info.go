@@ -0,0 +1,115 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"regexp"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceBigipFastTemplateInfo() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipFastTemplateInfoRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the FAST template, as template set/template",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[^/]+/[^/]+$`), "must be template set/template, e.g. examples/simple_http"),
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Title of the template",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the template",
+			},
+			"parameters": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Parameters of the template, sorted by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the parameter",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON schema type of the parameter",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the parameter",
+						},
+						"default": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "JSON encoded default value of the parameter",
+						},
+						"required": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the parameter must be set in fast_json",
+						},
+						"enum": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Allowed values of the parameter",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"parameters_schema": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON schema of the parameters of the template",
+			},
+		},
+	}
+}
+
+func dataSourceBigipFastTemplateInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	name := d.Get("name").(string)
+	log.Printf("[INFO] Reading FAST template %s", name)
+	s, err := getFastTemplateSchema(client, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var parameters []interface{}
+	for _, p := range s.parameters() {
+		parameters = append(parameters, map[string]interface{}{
+			"name":        p.name,
+			"type":        p.kind,
+			"description": p.description,
+			"default":     p.defaultJSON,
+			"required":    p.required,
+			"enum":        p.enum,
+		})
+	}
+	parametersSchema, _ := json.Marshal(s.raw)
+	d.SetId(name)
+	_ = d.Set("title", s.title)
+	_ = d.Set("description", s.description)
+	_ = d.Set("parameters", parameters)
+	_ = d.Set("parameters_schema", string(parametersSchema))
+	return nil
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ephemeralPrefix is the name prefix of the nodes and pool members the system creates for resolved FQDN addresses.
+const ephemeralPrefix = "_auto_"
+
+func dataSourceBigipLtmPoolEphemeralMembers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipLtmPoolEphemeralMembersRead,
+		Schema: map[string]*schema.Schema{
+			"pool": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Full path of the pool",
+				ValidateFunc: validateF5NameWithDirectory,
+			},
+			"fqdn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the ephemeral members created for this FQDN",
+			},
+			"members": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Ephemeral pool members created for FQDN nodes",
+				Elem:        ephemeralPoolMemberSchema(),
+			},
+		},
+	}
+}
+
+func ephemeralPoolMemberSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the ephemeral pool member",
+			},
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the ephemeral pool member",
+			},
+			"address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Resolved IP address of the ephemeral pool member",
+			},
+			"fqdn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "FQDN the ephemeral pool member was resolved from",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current monitor state of the ephemeral pool member",
+			},
+			"session": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Current session state of the ephemeral pool member",
+			},
+		},
+	}
+}
+
+func dataSourceBigipLtmPoolEphemeralMembersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	poolName := d.Get("pool").(string)
+	log.Printf("[INFO] Reading ephemeral members of pool %s", poolName)
+
+	nodes, err := client.PoolMembers(poolName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving pool (%s) members: %s", poolName, err))
+	}
+	var members []bigip.PoolMember
+	if nodes != nil {
+		members = filterEphemeralPoolMembers(nodes.PoolMembers, d.Get("fqdn").(string), "")
+	}
+	if err := d.Set("members", flattenEphemeralPoolMembers(members)); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting ephemeral members of pool (%s): %s", poolName, err))
+	}
+	d.SetId(poolName)
+	return nil
+}
+
+// filterEphemeralPoolMembers returns the ephemeral members, optionally limited to the ones resolved
+// from fqdn and listening on port.
+func filterEphemeralPoolMembers(members []bigip.PoolMember, fqdn, port string) []bigip.PoolMember {
+	var ephemeral []bigip.PoolMember
+	for _, member := range members {
+		if !strings.HasPrefix(member.Name, ephemeralPrefix) {
+			continue
+		}
+		if fqdn != "" && member.FQDN.Name != fqdn {
+			continue
+		}
+		if port != "" && !strings.HasSuffix(member.Name, ":"+port) && !strings.HasSuffix(member.Name, "."+port) {
+			continue
+		}
+		ephemeral = append(ephemeral, member)
+	}
+	sort.Slice(ephemeral, func(i, j int) bool {
+		return ephemeral[i].Name < ephemeral[j].Name
+	})
+	return ephemeral
+}
+
+func flattenEphemeralPoolMembers(members []bigip.PoolMember) []interface{} {
+	result := make([]interface{}, 0, len(members))
+	for _, member := range members {
+		result = append(result, map[string]interface{}{
+			"name":      member.Name,
+			"full_path": member.FullPath,
+			"address":   member.Address,
+			"fqdn":      member.FQDN.Name,
+			"state":     member.State,
+			"session":   member.Session,
+		})
+	}
+	return result
+}
+
+// fqdnResolvedAddresses returns the addresses of the ephemeral nodes created for the FQDN node in partition.
+func fqdnResolvedAddresses(client *bigip.BigIP, partition, fqdn string) ([]string, error) {
+	nodes, err := client.Nodes()
+	if err != nil {
+		return nil, err
+	}
+	addresses := []string{}
+	if nodes == nil {
+		return addresses, nil
+	}
+	for _, node := range nodes.Nodes {
+		if strings.HasPrefix(node.Name, ephemeralPrefix) && node.FQDN.Name == fqdn && node.Partition == partition {
+			addresses = append(addresses, node.Address)
+		}
+	}
+	sort.Strings(addresses)
+	return addresses, nil
+}
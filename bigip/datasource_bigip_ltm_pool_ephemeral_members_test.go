@@ -0,0 +1,83 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+package bigip
+
+import (
+	"testing"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAccBigipLtmPoolEphemeralMembers_basic(t *testing.T) {
+	t.Parallel()
+	dataSourceName := "data.bigip_ltm_pool_ephemeral_members.test"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAcctPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPoolsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCheckPoolEphemeralMembersConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "pool", "/Common/test-pool-ephemeral"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "members.#"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckPoolEphemeralMembersConfig() string {
+	return `
+resource "bigip_ltm_pool" "test-pool" {
+  name = "/Common/test-pool-ephemeral"
+}
+resource "bigip_ltm_pool_attachment" "test-pool-fqdn" {
+  pool = bigip_ltm_pool.test-pool.name
+  node = "www.f5.com:443"
+}
+data "bigip_ltm_pool_ephemeral_members" "test" {
+  pool = bigip_ltm_pool_attachment.test-pool-fqdn.pool
+  fqdn = "www.f5.com"
+}
+`
+}
+
+func TestFilterEphemeralPoolMembers(t *testing.T) {
+	members := []bigip.PoolMember{
+		{Name: "www.example.com:80", FullPath: "/Common/www.example.com:80"},
+		{Name: "_auto_10.0.0.2:80", FullPath: "/Common/_auto_10.0.0.2:80", Address: "10.0.0.2"},
+		{Name: "_auto_10.0.0.1:80", FullPath: "/Common/_auto_10.0.0.1:80", Address: "10.0.0.1"},
+		{Name: "_auto_10.0.0.1:443", FullPath: "/Common/_auto_10.0.0.1:443", Address: "10.0.0.1"},
+		{Name: "_auto_10.1.0.1:80", FullPath: "/Common/_auto_10.1.0.1:80", Address: "10.1.0.1"},
+		{Name: "_auto_2001:db8::1.80", FullPath: "/Common/_auto_2001:db8::1.80", Address: "2001:db8::1"},
+	}
+	for i := 1; i < 4; i++ {
+		members[i].FQDN.Name = "www.example.com"
+	}
+	members[4].FQDN.Name = "api.example.com"
+	members[5].FQDN.Name = "www.example.com"
+
+	all := filterEphemeralPoolMembers(members, "", "")
+	assert.Len(t, all, 5)
+	assert.Equal(t, "_auto_10.0.0.1:443", all[0].Name)
+
+	byFqdn := filterEphemeralPoolMembers(members, "www.example.com", "80")
+	assert.Len(t, byFqdn, 3)
+	assert.Equal(t, "_auto_10.0.0.1:80", byFqdn[0].Name)
+	assert.Equal(t, "_auto_10.0.0.2:80", byFqdn[1].Name)
+	assert.Equal(t, "_auto_2001:db8::1.80", byFqdn[2].Name)
+
+	flat := flattenEphemeralPoolMembers(byFqdn)
+	assert.Equal(t, "10.0.0.1", flat[0].(map[string]interface{})["address"])
+	assert.Equal(t, "www.example.com", flat[0].(map[string]interface{})["fqdn"])
+
+	assert.Empty(t, filterEphemeralPoolMembers(members, "missing.example.com", ""))
+}
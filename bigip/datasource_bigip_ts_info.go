@@ -0,0 +1,77 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBigipTsInfo() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceBigipTsInfoRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of Telemetry Streaming installed on the BIG-IP",
+			},
+			"release": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Release of Telemetry Streaming installed on the BIG-IP",
+			},
+			"schema_current": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Latest declaration schema version supported",
+			},
+			"schema_minimum": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Oldest declaration schema version supported",
+			},
+		},
+	}
+}
+
+func dataSourceBigipTsInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading Telemetry Streaming info of %s", client.Host)
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "get",
+		URL:         tsInfoURL,
+		ContentType: "application/json",
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+			return diag.FromErr(fmt.Errorf("telemetry Streaming is not installed on %s: %v", client.Host, err))
+		}
+		return diag.FromErr(fmt.Errorf("error reading Telemetry Streaming info: %v", err))
+	}
+	var info struct {
+		Version       string `json:"version"`
+		Release       string `json:"release"`
+		SchemaCurrent string `json:"schemaCurrent"`
+		SchemaMinimum string `json:"schemaMinimum"`
+	}
+	if err := json.Unmarshal(resp, &info); err != nil {
+		return diag.FromErr(fmt.Errorf("error parsing Telemetry Streaming info: %v", err))
+	}
+	d.SetId(client.Host)
+	_ = d.Set("version", info.Version)
+	_ = d.Set("release", info.Release)
+	_ = d.Set("schema_current", info.SchemaCurrent)
+	_ = d.Set("schema_minimum", info.SchemaMinimum)
+	return nil
+}
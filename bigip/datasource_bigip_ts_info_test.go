@@ -0,0 +1,38 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceBigipTsInfoRead(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/shared/telemetry/info", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"nodeVersion": "v8.11.1", "version": "1.32.0", "release": "2", "schemaCurrent": "1.32.0", "schemaMinimum": "0.9.0"}`)
+	})
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceBigipTsInfo().Schema, map[string]interface{}{})
+	diags := dataSourceBigipTsInfoRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "1.32.0", d.Get("version"))
+	assert.Equal(t, "2", d.Get("release"))
+	assert.Equal(t, "0.9.0", d.Get("schema_minimum"))
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/efellowsbg/terraform-provider-bigip/schemas"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// declarationSchema is an embedded JSON schema for one or more schemaVersion values of a declarative API.
+// The supported versions are read from the schemaVersion property of the schema itself.
+type declarationSchema struct {
+	name         string
+	file         string
+	wrapperClass string
+
+	once     sync.Once
+	schema   *jsonschema.Schema
+	versions map[string]bool
+	pattern  *regexp.Regexp
+	err      error
+}
+
+var doDeclarationSchemas = []*declarationSchema{
+	{name: "DO", file: "doschema.json", wrapperClass: "DO"},
+}
+
+var as3DeclarationSchemas = []*declarationSchema{
+	{name: "AS3", file: "as3schema.json", wrapperClass: "AS3"},
+}
+
+func (s *declarationSchema) load() error {
+	s.once.Do(func() {
+		data, err := schemas.FS.ReadFile(s.file)
+		if err != nil {
+			s.err = err
+			return
+		}
+		var raw struct {
+			Properties struct {
+				SchemaVersion struct {
+					Enum    []string `json:"enum"`
+					Pattern string   `json:"pattern"`
+				} `json:"schemaVersion"`
+			} `json:"properties"`
+		}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			s.err = err
+			return
+		}
+		s.versions = make(map[string]bool)
+		for _, version := range raw.Properties.SchemaVersion.Enum {
+			s.versions[version] = true
+		}
+		if raw.Properties.SchemaVersion.Pattern != "" {
+			if s.pattern, err = regexp.Compile(raw.Properties.SchemaVersion.Pattern); err != nil {
+				s.err = err
+				return
+			}
+		}
+		compiler := jsonschema.NewCompiler()
+		compiler.Draft = jsonschema.Draft7
+		if err := compiler.AddResource(s.file, strings.NewReader(string(data))); err != nil {
+			s.err = err
+			return
+		}
+		s.schema, s.err = compiler.Compile(s.file)
+	})
+	return s.err
+}
+
+func (s *declarationSchema) supports(version string) bool {
+	return s.versions[version] || (s.pattern != nil && s.pattern.MatchString(version))
+}
+
+// validateDeclaration validates a declaration, optionally wrapped in its request class, against the
+// embedded schema matching its schemaVersion. Declarations without a matching schema are not validated.
+func validateDeclaration(candidates []*declarationSchema, value string, path cty.Path) diag.Diagnostics {
+	if len(candidates) == 0 {
+		return nil
+	}
+	var doc interface{}
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	if err := decoder.Decode(&doc); err != nil {
+		// invalid JSON is reported by the attribute's other validations
+		return nil
+	}
+	declaration, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	prefix := ""
+	if declaration["class"] == candidates[0].wrapperClass {
+		if declaration, ok = declaration["declaration"].(map[string]interface{}); !ok {
+			return nil
+		}
+		prefix = "/declaration"
+	}
+	version, _ := declaration["schemaVersion"].(string)
+	for _, candidate := range candidates {
+		if err := candidate.load(); err != nil {
+			log.Printf("[ERROR] Unable to load the embedded %s schema %s: %v", candidate.name, candidate.file, err)
+			return nil
+		}
+		if candidate.supports(version) {
+			return declarationSchemaDiags(candidate, candidate.schema.Validate(declaration), prefix, path)
+		}
+	}
+	log.Printf("[WARN] No embedded %s schema for schemaVersion %q, skipping offline validation", candidates[0].name, version)
+	return nil
+}
+
+func declarationSchemaDiags(s *declarationSchema, err error, prefix string, path cty.Path) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Unable to validate %s declaration", s.name),
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+	var diags diag.Diagnostics
+	seen := make(map[string]bool)
+	for _, cause := range declarationSchemaErrors(validationErr) {
+		location := prefix + cause.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		detail := fmt.Sprintf("%s: %s", location, cause.Message)
+		if seen[detail] {
+			continue
+		}
+		seen[detail] = true
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Invalid %s declaration", s.name),
+			Detail:        detail,
+			AttributePath: path,
+		})
+	}
+	return diags
+}
+
+// declarationSchemaErrors returns the innermost causes of a validation error, which point at the
+// offending property instead of the enclosing object. For oneOf and anyOf, the alternatives rejected by
+// a const discriminator such as class are left out when another alternative matched it.
+func declarationSchemaErrors(err *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(err.Causes) == 0 {
+		return []*jsonschema.ValidationError{err}
+	}
+	alternatives := err.Causes
+	if strings.HasSuffix(err.KeywordLocation, "/oneOf") || strings.HasSuffix(err.KeywordLocation, "/anyOf") {
+		var discriminated []*jsonschema.ValidationError
+		for _, cause := range err.Causes {
+			if !hasConstSchemaError(cause) {
+				discriminated = append(discriminated, cause)
+			}
+		}
+		if len(discriminated) > 0 {
+			alternatives = discriminated
+		}
+	}
+	var causes []*jsonschema.ValidationError
+	for _, cause := range alternatives {
+		causes = append(causes, declarationSchemaErrors(cause)...)
+	}
+	return causes
+}
+
+func hasConstSchemaError(err *jsonschema.ValidationError) bool {
+	if strings.HasSuffix(err.KeywordLocation, "/const") {
+		return true
+	}
+	for _, cause := range err.Causes {
+		if hasConstSchemaError(cause) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAs3PoolDeclaration = `{
+  "class": "AS3",
+  "action": "deploy",
+  "declaration": {
+    "class": "ADC",
+    "schemaVersion": "%s",
+    "Sample_01": {
+      "class": "Tenant",
+      "A1": {
+        "class": "Application",
+        "service": {
+          "class": "Service_HTTP",
+          "virtualAddresses": ["10.0.1.10"],
+          "pool": "web_pool"
+        },
+        "web_pool": {
+          "class": "Pool",
+          "monitors": ["http"],
+          "members": [
+            {
+              "%s": 80,
+              "serverAddresses": ["192.0.1.10", "192.0.1.11"]
+            }
+          ]
+        }
+      }
+    }
+  }
+}`
+
+const testDoDeclaration = `{
+  "schemaVersion": "%s",
+  "class": "Device",
+  "async": true,
+  "Common": {
+    "class": "Tenant",
+    "hostname": "bigip.example.com",
+    "myDns": {
+      "class": "DNS",
+      "nameServers": ["8.8.8.8"],
+      "search": ["f5.com"]
+    },
+    "myNtp": {
+      "class": "NTP",
+      "servers": ["0.pool.ntp.org"],
+      "timezone": "UTC"
+    },
+    "myProvisioning": {
+      "class": "Provision",
+      "ltm": "%s"
+    }
+  }
+}`
+
+func TestValidateAs3Declaration(t *testing.T) {
+	path := cty.GetAttrPath("as3_json")
+
+	t.Run("Valid", func(t *testing.T) {
+		for _, file := range []string{"../examples/as3/as3_example1.json", "../examples/as3/as3_per_app_example1.json"} {
+			data, err := os.ReadFile(file)
+			assert.NoError(t, err)
+			assert.Empty(t, validateDeclaration(as3DeclarationSchemas, string(data), path), file)
+		}
+		assert.Empty(t, validateDeclaration(as3DeclarationSchemas, fmt.Sprintf(testAs3PoolDeclaration, "3.50.0", "servicePort"), path))
+	})
+
+	t.Run("Pool member typo", func(t *testing.T) {
+		diags := validateDeclaration(as3DeclarationSchemas, fmt.Sprintf(testAs3PoolDeclaration, "3.50.0", "servicePrt"), path)
+		if assert.NotEmpty(t, diags) {
+			assert.True(t, diags.HasError())
+			assert.Equal(t, "Invalid AS3 declaration", diags[0].Summary)
+			assert.Equal(t, path, diags[0].AttributePath)
+			for _, d := range diags {
+				assert.Contains(t, d.Detail, "/declaration/Sample_01/A1/web_pool/members/0")
+			}
+			assert.Contains(t, diags[len(diags)-1].Detail, "servicePort")
+		}
+	})
+
+	t.Run("Optional pool member property typo", func(t *testing.T) {
+		declaration := strings.Replace(fmt.Sprintf(testAs3PoolDeclaration, "3.50.0", "servicePort"),
+			`"servicePort": 80,`, `"servicePort": 80, "connectionLimt": 100,`, 1)
+		diags := validateDeclaration(as3DeclarationSchemas, declaration, path)
+		if assert.NotEmpty(t, diags) {
+			assert.Contains(t, diags[len(diags)-1].Detail, "/declaration/Sample_01/A1/web_pool/members/0")
+			assert.Contains(t, diags[len(diags)-1].Detail, "connectionLimt")
+		}
+	})
+
+	t.Run("Pool property typo", func(t *testing.T) {
+		declaration := strings.Replace(fmt.Sprintf(testAs3PoolDeclaration, "3.50.0", "servicePort"),
+			`"monitors": ["http"],`, `"monitors": ["http"], "slowRamptime": 10,`, 1)
+		diags := validateDeclaration(as3DeclarationSchemas, declaration, path)
+		if assert.NotEmpty(t, diags) {
+			assert.Contains(t, diags[len(diags)-1].Detail, "/declaration/Sample_01/A1/web_pool")
+			assert.Contains(t, diags[len(diags)-1].Detail, "slowRamptime")
+		}
+	})
+
+	t.Run("Service discovery pool member", func(t *testing.T) {
+		declaration := strings.Replace(fmt.Sprintf(testAs3PoolDeclaration, "3.50.0", "servicePort"),
+			`"servicePort": 80,`, `"servicePort": 80, "addressDiscovery": "consul", "uri": "http://consul:8500/v1/catalog/nodes",`, 1)
+		assert.Empty(t, validateDeclaration(as3DeclarationSchemas, declaration, path))
+	})
+
+	t.Run("Pool member property type", func(t *testing.T) {
+		declaration := strings.Replace(fmt.Sprintf(testAs3PoolDeclaration, "3.50.0", "servicePort"),
+			`"servicePort": 80,`, `"servicePort": 80, "shareNodes": "yes",`, 1)
+		diags := validateDeclaration(as3DeclarationSchemas, declaration, path)
+		if assert.NotEmpty(t, diags) {
+			assert.Contains(t, diags[len(diags)-1].Detail, "/declaration/Sample_01/A1/web_pool/members/0/shareNodes")
+		}
+	})
+
+	t.Run("Unsupported version", func(t *testing.T) {
+		assert.Empty(t, validateDeclaration(as3DeclarationSchemas, fmt.Sprintf(testAs3PoolDeclaration, "4.0.0", "servicePrt"), path))
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		assert.Empty(t, validateDeclaration(as3DeclarationSchemas, `{"class": "AS3"`, path))
+	})
+}
+
+func TestValidateDoDeclaration(t *testing.T) {
+	path := cty.GetAttrPath("do_json")
+
+	assert.Empty(t, validateDeclaration(doDeclarationSchemas, fmt.Sprintf(testDoDeclaration, "1.12.0", "nominal"), path))
+
+	diags := validateDeclaration(doDeclarationSchemas, fmt.Sprintf(testDoDeclaration, "1.12.0", "enabled"), path)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Invalid DO declaration", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "/Common/myProvisioning/ltm")
+	}
+
+	wrapped := `{"class": "DO", "declaration": ` + fmt.Sprintf(testDoDeclaration, "1.12.0", "enabled") + `}`
+	diags = validateDeclaration(doDeclarationSchemas, wrapped, path)
+	if assert.Len(t, diags, 1) {
+		assert.Contains(t, diags[0].Detail, "/declaration/Common/myProvisioning/ltm")
+	}
+
+	// no embedded schema covers newer versions
+	assert.Empty(t, validateDeclaration(doDeclarationSchemas, fmt.Sprintf(testDoDeclaration, "1.40.0", "enabled"), path))
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// bigipVirtualEdition is the platform ID of BIG-IP Virtual Edition.
+const bigipVirtualEdition = "Z100"
+
+// bigipDeviceInfo describes the BIG-IP the provider is connected to.
+type bigipDeviceInfo struct {
+	version            string
+	build              string
+	platformID         string
+	marketingName      string
+	hostname           string
+	baseMac            string
+	haState            string
+	licensedModules    []string
+	provisionedModules map[string]string
+	availableModules   []string
+}
+
+// getBigipDeviceInfo reads the version, the self device and the provisioning of the BIG-IP.
+func getBigipDeviceInfo(client *bigip.BigIP) (*bigipDeviceInfo, error) {
+	version, err := client.BigipVersion()
+	if err != nil {
+		return nil, fmt.Errorf("error reading the version of %s: %v", client.Host, err)
+	}
+	info := &bigipDeviceInfo{
+		version:            version.Entries.HTTPSLocalhostMgmtTmCliVersion0.NestedStats.Entries.Active.Description,
+		provisionedModules: make(map[string]string),
+	}
+	devices, err := client.GetDevices()
+	if err != nil {
+		return nil, fmt.Errorf("error reading the devices of %s: %v", client.Host, err)
+	}
+	for _, device := range devices {
+		if device.SelfDevice != "true" {
+			continue
+		}
+		info.build = device.Build
+		info.platformID = device.PlatformID
+		info.marketingName = device.MarketingName
+		info.hostname = device.Hostname
+		info.baseMac = device.BaseMac
+		info.haState = device.FailoverState
+		// active modules are the license bundles, e.g. Best Bundle, VE-1G|KEY|Rate Shaping|...
+		for _, module := range device.ActiveModules {
+			info.licensedModules = append(info.licensedModules, strings.SplitN(module, "|", 2)[0])
+		}
+		sort.Strings(info.licensedModules)
+	}
+	provisions, err := getProvisioning(client)
+	if err != nil {
+		return nil, fmt.Errorf("error reading the provisioning of %s: %v", client.Host, err)
+	}
+	for _, module := range provisions {
+		info.availableModules = append(info.availableModules, module.Name)
+		if module.Level != "none" {
+			info.provisionedModules[module.Name] = module.Level
+		}
+	}
+	return info, nil
+}
+
+// versionAtLeast reports whether the TMOS version, e.g. 17.1.0.1, is at least min, e.g. 14.1.
+func versionAtLeast(version, min string) bool {
+	parts, minParts := strings.Split(version, "."), strings.Split(min, ".")
+	for i, minPart := range minParts {
+		m, _ := strconv.Atoi(minPart)
+		v := 0
+		if i < len(parts) {
+			v, _ = strconv.Atoi(parts[i])
+		}
+		if v != m {
+			return v > m
+		}
+	}
+	return true
+}
+
+// bigipCapability is what a resource requires of the BIG-IP.
+type bigipCapability struct {
+	// modules are the modules of which one must be provisioned
+	modules []string
+	// minVersion is the first TMOS version supporting the resource
+	minVersion string
+	// hardware is set when the resource is not supported by BIG-IP Virtual Edition
+	hardware bool
+}
+
+// checkBigipCapability checks that the platform and the version of the BIG-IP support the resource, and returns
+// an error explaining what is missing otherwise. A module the platform provides but that is not provisioned yet
+// passes, as it may be provisioned in the same apply.
+func checkBigipCapability(client *bigip.BigIP, info *bigipDeviceInfo, resource string, capability bigipCapability) error {
+	if capability.hardware && info.platformID == bigipVirtualEdition {
+		return fmt.Errorf("%s is not supported on BIG-IP Virtual Edition: %s is platform %s (%s)", resource, client.Host, info.platformID, info.marketingName)
+	}
+	if capability.minVersion != "" && info.version != "" && !versionAtLeast(info.version, capability.minVersion) {
+		return fmt.Errorf("%s requires BIG-IP %s or later: %s runs %s", resource, capability.minVersion, client.Host, info.version)
+	}
+	if len(capability.modules) == 0 {
+		return nil
+	}
+	var available []string
+	for _, module := range capability.modules {
+		if level := info.provisionedModules[module]; level != "" && level != "none" {
+			return nil
+		}
+		for _, name := range info.availableModules {
+			if name == module {
+				available = append(available, module)
+			}
+		}
+	}
+	if len(available) == 0 {
+		return fmt.Errorf("%s requires module %s, which platform %s (%s) of %s does not support", resource,
+			strings.Join(capability.modules, " or "), info.platformID, info.marketingName, client.Host)
+	}
+	// the module may be provisioned by bigip_sys_provisioning or bigip_sys_provision in the same apply, which is
+	// not known when the resource is planned: the BIG-IP rejects the resource on apply if it is not
+	log.Printf("[WARN] %s requires module %s, which is not provisioned on %s yet: it must be provisioned before %s is created",
+		resource, strings.Join(available, " or "), client.Host, resource)
+	return nil
+}
+
+// customizeDiffBigipCapability fails the plan of a new resource the BIG-IP does not support, instead of failing
+// on apply. The check is skipped when the BIG-IP cannot be read.
+func customizeDiffBigipCapability(resource string, capability bigipCapability) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*bigip.BigIP)
+		if !ok || d.Id() != "" {
+			return nil
+		}
+		info, err := getBigipDeviceInfo(client)
+		if err != nil {
+			log.Printf("[WARN] Unable to check that %s supports %s, it is checked on apply: %v", client.Host, resource, err)
+			return nil
+		}
+		return checkBigipCapability(client, info, resource, capability)
+	}
+}
@@ -0,0 +1,356 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+)
+
+const (
+	doDeclareURL = "/mgmt/shared/declarative-onboarding"
+	doTaskURL    = doDeclareURL + "/task"
+	doInspectURL = doDeclareURL + "/inspect"
+)
+
+var (
+	doTaskPollInterval = 3 * time.Second
+	// doUnreachableTimeout bounds how long the BIG-IP may stay unreachable when DO did not announce a reboot.
+	doUnreachableTimeout = 5 * time.Minute
+)
+
+// doDriftClasses are the onboarded classes whose settings are compared with the inspected device
+// configuration. Singleton classes are matched by class, the others by name.
+var doDriftClasses = map[string]bool{
+	"System": true,
+	"DNS":    true,
+	"NTP":    true,
+	"VLAN":   false,
+	"SelfIp": false,
+}
+
+type doTaskResult struct {
+	Code           int      `json:"code"`
+	Status         string   `json:"status"`
+	Message        string   `json:"message"`
+	Errors         []string `json:"errors,omitempty"`
+	RebootRequired bool     `json:"rebootRequired,omitempty"`
+}
+
+// doTask is the state of a Declarative Onboarding request as returned by /mgmt/shared/declarative-onboarding/task.
+type doTask struct {
+	ID          string          `json:"id"`
+	Result      doTaskResult    `json:"result"`
+	Declaration json.RawMessage `json:"declaration,omitempty"`
+}
+
+// pending reports whether DO is still processing the declaration, rebooting the BIG-IP included.
+func (t *doTask) pending() bool {
+	return t.Result.Status == "" || t.Result.Status == "RUNNING" || t.rebooting()
+}
+
+// rebooting reports whether DO is rebooting the BIG-IP, after which it resumes the task.
+func (t *doTask) rebooting() bool {
+	return strings.HasPrefix(t.Result.Status, "REBOOTING")
+}
+
+func (t *doTask) failed() bool {
+	return t.Result.Status == "ERROR" || t.Result.Status == "ROLLING_BACK" || t.Result.Code >= 400
+}
+
+func (t *doTask) String() string {
+	out, _ := json.Marshal(t.Result)
+	return string(out)
+}
+
+// parseDoTask reads a task from a DO response, which is either the task or a list holding it.
+func parseDoTask(resp []byte) (*doTask, error) {
+	var tasks []doTask
+	if err := json.Unmarshal(resp, &tasks); err == nil {
+		if len(tasks) == 0 {
+			return nil, fmt.Errorf("no DO task in the response")
+		}
+		return &tasks[0], nil
+	}
+	task := &doTask{}
+	if err := json.Unmarshal(resp, task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+// postDoDeclaration posts the declaration and returns its task without waiting for it.
+func postDoDeclaration(client *bigip.BigIP, doJson string) (*doTask, error) {
+	log.Printf("[DEBUG] Submitting DO declaration to %s", client.Host)
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "post",
+		URL:         doDeclareURL + "/",
+		Body:        doJson,
+		ContentType: "application/json",
+	})
+	task, parseErr := parseDoTask(resp)
+	if err != nil {
+		if parseErr == nil && task.ID != "" {
+			return task, fmt.Errorf("DO declaration failed: %s", task)
+		}
+		return nil, fmt.Errorf("error posting DO declaration: %v", err)
+	}
+	if parseErr != nil || task.ID == "" {
+		return nil, fmt.Errorf("unable to read the DO task from the response %s: %v", string(resp), parseErr)
+	}
+	if task.failed() {
+		return task, fmt.Errorf("DO declaration failed: %s", task)
+	}
+	return task, nil
+}
+
+// completeDoTask waits for a task returned by postDoDeclaration or findDoTask and reports its failure.
+func completeDoTask(ctx context.Context, client *bigip.BigIP, task *doTask) (*doTask, error) {
+	if task.pending() {
+		waited, err := waitDoTask(ctx, client, task.ID)
+		if err != nil {
+			return waited, err
+		}
+		task = waited
+	}
+	if task.failed() {
+		return task, fmt.Errorf("DO declaration failed: %s", task)
+	}
+	return task, nil
+}
+
+// findDoTask returns the pending DO task of the declaration, so that an interrupted apply attaches to it
+// instead of posting the declaration again, or nil when DO is not processing it.
+func findDoTask(client *bigip.BigIP, doJson string) (*doTask, error) {
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "get",
+		URL:         doTaskURL,
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var tasks []doTask
+	if err := json.Unmarshal(resp, &tasks); err != nil {
+		return nil, fmt.Errorf("error parsing DO tasks: %v", err)
+	}
+	var desired interface{}
+	if err := json.Unmarshal([]byte(doJson), &desired); err != nil {
+		return nil, err
+	}
+	for i := range tasks {
+		var declaration interface{}
+		if !tasks[i].pending() || json.Unmarshal(tasks[i].Declaration, &declaration) != nil {
+			continue
+		}
+		if reflect.DeepEqual(desired, declaration) {
+			return &tasks[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// getDoTask reads a DO task. DO answers 202 while the task runs and an error status once it failed, with
+// the task in the body either way.
+func getDoTask(client *bigip.BigIP, id string) (*doTask, error) {
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "get",
+		URL:         doTaskURL + "/" + id,
+		ContentType: "application/json",
+	})
+	task, parseErr := parseDoTask(resp)
+	if err != nil && (parseErr != nil || task.ID == "") {
+		return nil, err
+	}
+	if parseErr != nil {
+		return nil, fmt.Errorf("error parsing DO task (%s): %v", id, parseErr)
+	}
+	return task, nil
+}
+
+// waitDoTask polls the DO task until it is no longer pending. The REST API goes away while DO reboots the
+// BIG-IP or restarts its services, so polling errors are retried: until the timeout of ctx when DO announced
+// a reboot, and for doUnreachableTimeout otherwise.
+func waitDoTask(ctx context.Context, client *bigip.BigIP, id string) (*doTask, error) {
+	task := &doTask{ID: id}
+	rebootExpected := false
+	var unreachableSince time.Time
+	for {
+		polled, err := getDoTask(client, id)
+		switch {
+		case err != nil && isDoNotFound(err) && unreachableSince.IsZero() && !rebootExpected:
+			return task, fmt.Errorf("DO task (%s) not found: %v", id, err)
+		case err != nil:
+			if unreachableSince.IsZero() {
+				unreachableSince = time.Now()
+				log.Printf("[INFO] BIG-IP %s REST API went away while polling DO task %s: %v", client.Host, id, err)
+			}
+			if !rebootExpected && time.Since(unreachableSince) > doUnreachableTimeout {
+				return task, fmt.Errorf("BIG-IP unreachable for %s while polling DO task (%s) without a reboot: %v", doUnreachableTimeout, id, err)
+			}
+		default:
+			if !unreachableSince.IsZero() {
+				log.Printf("[INFO] BIG-IP %s REST API is back after %s", client.Host, time.Since(unreachableSince).Round(time.Second))
+				unreachableSince = time.Time{}
+			}
+			if !polled.pending() {
+				log.Printf("[DEBUG] DO task %s completed: %s", id, polled)
+				return polled, nil
+			}
+			if polled.rebooting() && !rebootExpected {
+				log.Printf("[INFO] DO is rebooting BIG-IP %s, waiting for it to resume task %s", client.Host, id)
+			}
+			rebootExpected = rebootExpected || polled.rebooting() || polled.Result.RebootRequired
+			task = polled
+		}
+		select {
+		case <-ctx.Done():
+			return task, fmt.Errorf("timed out waiting for DO task (%s), last status %s: %v", id, task, ctx.Err())
+		case <-time.After(doTaskPollInterval):
+		}
+	}
+}
+
+func isDoNotFound(err error) bool {
+	return strings.Contains(err.Error(), "404") || strings.Contains(strings.ToLower(err.Error()), "not found")
+}
+
+// inspectDo returns the Common tenant of the configuration read from the device by the DO inspect endpoint.
+func inspectDo(client *bigip.BigIP) (map[string]interface{}, error) {
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "get",
+		URL:         doInspectURL,
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+	task, err := parseDoTask(resp)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing DO inspect response: %v", err)
+	}
+	var declaration map[string]interface{}
+	if err := json.Unmarshal(task.Declaration, &declaration); err != nil {
+		return nil, fmt.Errorf("error parsing DO inspect declaration: %v", err)
+	}
+	common := doCommon(declaration)
+	if common == nil {
+		return nil, fmt.Errorf("no Common tenant in the DO inspect response")
+	}
+	return common, nil
+}
+
+// doCommon returns the Common tenant of a DO or Device declaration.
+func doCommon(declaration map[string]interface{}) map[string]interface{} {
+	if inner, ok := declaration["declaration"].(map[string]interface{}); ok {
+		declaration = inner
+	}
+	common, _ := declaration["Common"].(map[string]interface{})
+	return common
+}
+
+// doDeclarationDrift compares the onboarded settings of the declaration with the Common tenant inspected on
+// the device. It returns the JSON pointers of the drifted settings and the declaration with the inspected
+// values, objects missing on the device being removed from it.
+func doDeclarationDrift(doJson string, observed map[string]interface{}) (string, []string, error) {
+	var declaration map[string]interface{}
+	if err := json.Unmarshal([]byte(doJson), &declaration); err != nil {
+		return "", nil, err
+	}
+	common := doCommon(declaration)
+	if common == nil {
+		return doJson, nil, nil
+	}
+	var drift []string
+	for name, value := range common {
+		pointer := "/Common/" + name
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			if name == "hostname" && observed[name] != nil && !doValueEqual(value, observed[name]) {
+				common[name] = observed[name]
+				drift = append(drift, pointer)
+			}
+			continue
+		}
+		class, _ := object["class"].(string)
+		singleton, tracked := doDriftClasses[class]
+		if !tracked {
+			continue
+		}
+		actual := doObservedObject(observed, name, class, singleton)
+		if actual == nil {
+			delete(common, name)
+			drift = append(drift, pointer)
+			continue
+		}
+		for property, desired := range object {
+			if property == "class" || actual[property] == nil || doValueEqual(desired, actual[property]) {
+				continue
+			}
+			object[property] = actual[property]
+			drift = append(drift, pointer+"/"+property)
+		}
+	}
+	if len(drift) == 0 {
+		return doJson, nil, nil
+	}
+	sort.Strings(drift)
+	out, err := json.Marshal(declaration)
+	return string(out), drift, err
+}
+
+func doObservedObject(observed map[string]interface{}, name, class string, singleton bool) map[string]interface{} {
+	if object, ok := observed[name].(map[string]interface{}); ok && object["class"] == class {
+		return object
+	}
+	if !singleton {
+		return nil
+	}
+	for _, value := range observed {
+		if object, ok := value.(map[string]interface{}); ok && object["class"] == class {
+			return object
+		}
+	}
+	return nil
+}
+
+// doValueEqual compares a configured value with the inspected one. Properties the device does not report
+// are ignored, and scalars are compared by their text as DO reports some numbers as strings.
+func doValueEqual(desired, actual interface{}) bool {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range desiredValue {
+			if actualValue[key] != nil && !doValueEqual(value, actualValue[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok || len(actualValue) != len(desiredValue) {
+			return false
+		}
+		for i := range desiredValue {
+			if !doValueEqual(desiredValue[i], actualValue[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(desired, actual) || fmt.Sprint(desired) == fmt.Sprint(actual)
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeployDoTask(t *testing.T) {
+	doTaskPollInterval = time.Millisecond
+	defer func() { doTaskPollInterval = 3 * time.Second }()
+	setup()
+	defer teardown()
+
+	var polls int32
+	mux.HandleFunc("/mgmt/shared/declarative-onboarding/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = fmt.Fprint(w, `{"id": "task-1", "result": {"class": "Result", "code": 202, "status": "RUNNING", "message": "processing"}}`)
+	})
+	mux.HandleFunc("/mgmt/shared/declarative-onboarding/task", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `[
+			{"id": "task-0", "result": {"code": 200, "status": "OK"}, "declaration": {"class": "Device", "hostname": "a"}},
+			{"id": "task-3", "result": {"code": 202, "status": "RUNNING"}, "declaration": {"class": "Device", "hostname": "b"}}
+		]`)
+	})
+	mux.HandleFunc("/mgmt/shared/declarative-onboarding/task/task-1", func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&polls, 1) {
+		case 1:
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = fmt.Fprint(w, `{"id": "task-1", "result": {"code": 202, "status": "REBOOTING_AND_RESUMING", "message": "reboot required"}}`)
+		case 2, 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			_, _ = fmt.Fprint(w, `{"id": "task-1", "result": {"code": 200, "status": "OK", "message": "success", "rebootRequired": true}}`)
+		}
+	})
+	mux.HandleFunc("/mgmt/shared/declarative-onboarding/task/task-2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = fmt.Fprint(w, `{"id": "task-2", "result": {"code": 422, "status": "ERROR", "message": "invalid config", "errors": ["vlan internal is in use"]}}`)
+	})
+	mux.HandleFunc("/mgmt/shared/declarative-onboarding/task/task-3", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+	task, err := postDoDeclaration(client, `{"class": "Device"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "task-1", task.ID)
+	assert.True(t, task.pending())
+
+	// the REST API going away after DO announced the reboot is waited for
+	doUnreachableTimeout = 0
+	defer func() { doUnreachableTimeout = 5 * time.Minute }()
+	task, err = completeDoTask(context.Background(), client, task)
+	assert.NoError(t, err)
+	assert.Equal(t, "OK", task.Result.Status)
+	assert.True(t, task.Result.RebootRequired)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&polls))
+
+	failed, err := completeDoTask(context.Background(), client, &doTask{ID: "task-2"})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"vlan internal is in use"}, failed.Result.Errors)
+
+	// an in-flight task of the same declaration is attached to instead of posting it again
+	found, err := findDoTask(client, `{"hostname": "b", "class": "Device"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "task-3", found.ID)
+	found, err = findDoTask(client, `{"class": "Device", "hostname": "a"}`)
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	// without a reboot announced, an unreachable BIG-IP fails the wait
+	_, err = completeDoTask(context.Background(), client, &doTask{ID: "task-3", Result: doTaskResult{Status: "RUNNING"}})
+	assert.ErrorContains(t, err, "unreachable")
+}
+
+func TestDoDeclarationDrift(t *testing.T) {
+	declaration := `{"class": "DO", "declaration": {"class": "Device", "schemaVersion": "1.30.0", "Common": {
+		"class": "Tenant",
+		"hostname": "bigip1.example.com",
+		"myDns": {"class": "DNS", "nameServers": ["8.8.8.8"], "search": ["f5.com"]},
+		"myNtp": {"class": "NTP", "servers": ["0.pool.ntp.org"], "timezone": "UTC"},
+		"internal": {"class": "VLAN", "tag": 4093, "mtu": 1500, "interfaces": [{"name": "1.1", "tagged": false}]},
+		"internal-self": {"class": "SelfIp", "address": "10.1.1.10/24", "vlan": "internal"},
+		"myProvisioning": {"class": "Provision", "ltm": "nominal"}
+	}}}`
+	inspected := func(changes func(common map[string]interface{})) map[string]interface{} {
+		common := map[string]interface{}{
+			"class":      "Tenant",
+			"hostname":   "bigip1.example.com",
+			"currentDNS": map[string]interface{}{"class": "DNS", "nameServers": []interface{}{"8.8.8.8"}, "search": []interface{}{"f5.com"}},
+			"currentNTP": map[string]interface{}{"class": "NTP", "servers": []interface{}{"0.pool.ntp.org"}, "timezone": "UTC"},
+			"internal": map[string]interface{}{"class": "VLAN", "tag": "4093", "mtu": float64(1500), "autoLastHop": "default",
+				"interfaces": []interface{}{map[string]interface{}{"name": "1.1", "tagged": false}}},
+			"internal-self": map[string]interface{}{"class": "SelfIp", "address": "10.1.1.10/24", "vlan": "internal", "trafficGroup": "traffic-group-local-only"},
+		}
+		if changes != nil {
+			changes(common)
+		}
+		return common
+	}
+
+	tests := []struct {
+		name     string
+		observed map[string]interface{}
+		drift    []string
+	}{
+		{
+			name:     "in sync",
+			observed: inspected(nil),
+		},
+		{
+			name: "hostname and dns changed",
+			observed: inspected(func(common map[string]interface{}) {
+				common["hostname"] = "other.example.com"
+				common["currentDNS"].(map[string]interface{})["nameServers"] = []interface{}{"1.1.1.1"}
+			}),
+			drift: []string{"/Common/hostname", "/Common/myDns/nameServers"},
+		},
+		{
+			name: "self ip removed and vlan tag changed",
+			observed: inspected(func(common map[string]interface{}) {
+				delete(common, "internal-self")
+				common["internal"].(map[string]interface{})["tag"] = float64(100)
+			}),
+			drift: []string{"/Common/internal-self", "/Common/internal/tag"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			drifted, drift, err := doDeclarationDrift(declaration, test.observed)
+			assert.NoError(t, err)
+			assert.Equal(t, test.drift, drift)
+			if len(test.drift) == 0 {
+				assert.Equal(t, declaration, drifted)
+				return
+			}
+			assert.NotEqual(t, declaration, drifted)
+			again, drift, err := doDeclarationDrift(drifted, test.observed)
+			assert.NoError(t, err)
+			assert.Empty(t, drift)
+			assert.Equal(t, drifted, again)
+		})
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const fastRenderURL = "/mgmt/shared/fast/render"
+
+// fastRenderSecrets are the AS3 properties holding secrets, e.g. the passphrase of a monitor, redacted from
+// the rendered declaration as it is shown in plans.
+var fastRenderSecrets = map[string]bool{
+	"passphrase": true,
+	"privateKey": true,
+}
+
+// fastAppConfig is the configuration of a FAST application resource: its ResourceData during apply, or its
+// ResourceDiff during plan.
+type fastAppConfig interface {
+	Get(key string) interface{}
+	GetOk(key string) (interface{}, bool)
+}
+
+// renderFastApplication renders the AS3 declaration of a template with parameters, without deploying it.
+func renderFastApplication(client *bigip.BigIP, template, fastJson string) (string, error) {
+	var params map[string]interface{}
+	if err := json.Unmarshal([]byte(fastJson), &params); err != nil {
+		return "", fmt.Errorf("error parsing FAST parameters: %v", err)
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"name":       template,
+		"parameters": params,
+	})
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "post",
+		URL:         fastRenderURL,
+		Body:        string(body),
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return "", fmt.Errorf("error rendering FAST template (%s): %v", template, err)
+	}
+	var rendered struct {
+		Message []struct {
+			AppDef interface{} `json:"appDef"`
+		} `json:"message"`
+	}
+	if err := json.Unmarshal(resp, &rendered); err != nil {
+		return "", fmt.Errorf("error parsing FAST render of template (%s): %v", template, err)
+	}
+	if len(rendered.Message) != 1 || rendered.Message[0].AppDef == nil {
+		return "", fmt.Errorf("FAST rendered %d declarations of template (%s), expected one", len(rendered.Message), template)
+	}
+	out, _ := json.Marshal(redactFastRender(rendered.Message[0].AppDef))
+	return string(out), nil
+}
+
+func redactFastRender(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if fastRenderSecrets[key] {
+				v[key] = "REDACTED"
+			} else {
+				v[key] = redactFastRender(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactFastRender(item)
+		}
+	}
+	return value
+}
+
+// fastAppRenderedAs3Diff returns the CustomizeDiff of a FAST application resource, setting rendered_as3 to
+// the AS3 declaration FAST renders from the parameters built by config. The declaration is rendered when
+// the application is created or changed, and is unknown when it cannot be rendered during plan.
+func fastAppRenderedAs3Diff(template string, config func(fastAppConfig) (string, error)) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		if d.Id() != "" && len(d.GetChangedKeysPrefix("")) == 0 {
+			return nil
+		}
+		client, ok := meta.(*bigip.BigIP)
+		if !ok || client == nil || !d.GetRawConfig().IsWhollyKnown() {
+			return d.SetNewComputed("rendered_as3")
+		}
+		params, err := config(d)
+		if err != nil {
+			return err
+		}
+		rendered, err := renderFastApplication(client, template, params)
+		if err != nil {
+			log.Printf("[WARN] Unable to render FAST template %s during plan: %v", template, err)
+			return d.SetNewComputed("rendered_as3")
+		}
+		return d.SetNew("rendered_as3", rendered)
+	}
+}
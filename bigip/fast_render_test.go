@@ -0,0 +1,92 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func handleFastRender(t *testing.T) {
+	mux.HandleFunc("/mgmt/shared/fast/render", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		var payload struct {
+			Name       string                 `json:"name"`
+			Parameters map[string]interface{} `json:"parameters"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		w.Header().Set("Content-Type", "application/json")
+		if payload.Name != "bigip-fast-templates/http" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = fmt.Fprintf(w, `{"code": 404, "message": "Could not find template: %s"}`, payload.Name)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"code": 200, "message": [{"appDef": {"class": "ADC", "%s": {"class": "Tenant", "%s": {"class": "Application",
+			"%s": {"class": "Service_HTTP", "virtualAddresses": ["10.1.1.1"]},
+			"%s_monitor": {"class": "Monitor", "monitorType": "http", "passphrase": {"ciphertext": "c2VjcmV0"}}}}}}]}`,
+			payload.Parameters["tenant_name"], payload.Parameters["app_name"], payload.Parameters["app_name"], payload.Parameters["app_name"])
+	})
+}
+
+func TestRenderFastApplication(t *testing.T) {
+	setup()
+	defer teardown()
+	handleFastRender(t)
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	rendered, err := renderFastApplication(client, "bigip-fast-templates/http", `{"tenant_name": "t1", "app_name": "app1"}`)
+	assert.NoError(t, err)
+	var decl map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(rendered), &decl))
+	app := decl["t1"].(map[string]interface{})["app1"].(map[string]interface{})
+	assert.Equal(t, "Service_HTTP", app["app1"].(map[string]interface{})["class"])
+	assert.Equal(t, "REDACTED", app["app1_monitor"].(map[string]interface{})["passphrase"], "secrets are redacted")
+
+	_, err = renderFastApplication(client, "bigip-fast-templates/missing", `{"tenant_name": "t1"}`)
+	assert.ErrorContains(t, err, "Could not find template")
+}
+
+func TestDataSourceBigipFastRenderRead(t *testing.T) {
+	setup()
+	defer teardown()
+	handleFastRender(t)
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceBigipFastRender().Schema, map[string]interface{}{
+		"template":  "bigip-fast-templates/http",
+		"fast_json": `{"tenant_name": "t1", "app_name": "app1"}`,
+	})
+	diags := dataSourceBigipFastRenderRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Contains(t, d.Get("rendered_as3"), `"virtualAddresses":["10.1.1.1"]`)
+	assert.NotEmpty(t, d.Id())
+}
+
+func TestGetFastHttpConfig(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceBigipHttpFastApp().Schema, map[string]interface{}{
+		"tenant":         "t1",
+		"application":    "app1",
+		"virtual_server": []interface{}{map[string]interface{}{"ip": "10.1.1.1", "port": 80}},
+	})
+	params, err := getFastHttpConfig(d)
+	assert.NoError(t, err)
+	assert.Contains(t, params, `"tenant_name":"t1"`)
+	assert.Contains(t, params, `"virtual_address":"10.1.1.1"`)
+}
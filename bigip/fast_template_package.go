@@ -0,0 +1,288 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fastParameterTypes are the types of FAST parameters, used in definitions and in {{name::type}} tags.
+var fastParameterTypes = map[string]bool{
+	"string":  true,
+	"text":    true,
+	"integer": true,
+	"number":  true,
+	"boolean": true,
+	"array":   true,
+	"object":  true,
+}
+
+// readFastTemplateDirectory returns the files of a template set directory by their slash separated path,
+// hidden files excluded.
+func readFastTemplateDirectory(dir string) (map[string]string, error) {
+	files := make(map[string]string)
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(info.Name(), ".") && path != dir {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(name)] = string(content)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading template set directory %s: %v", dir, err)
+	}
+	return files, nil
+}
+
+// validateFastTemplateSet checks the syntax of the templates and the schema of their parameters.
+func validateFastTemplateSet(files map[string]string) error {
+	if len(files) == 0 {
+		return fmt.Errorf("the template set has no file")
+	}
+	templates := 0
+	for _, name := range sortedFastFileNames(files) {
+		var err error
+		switch strings.ToLower(filepath.Ext(name)) {
+		case ".yaml", ".yml":
+			templates++
+			err = validateFastYamlTemplate(files[name])
+		case ".mst", ".mustache":
+			templates++
+			err = validateMustache(files[name])
+		case ".json":
+			var schema interface{}
+			err = json.Unmarshal([]byte(files[name]), &schema)
+		}
+		if err != nil {
+			return fmt.Errorf("invalid template %s: %v", name, err)
+		}
+	}
+	if templates == 0 {
+		return fmt.Errorf("the template set has no .yaml, .yml or .mst template")
+	}
+	return nil
+}
+
+// validateFastYamlTemplate checks a YAML template: its mustache text, and the definitions and default
+// values of its parameters.
+func validateFastYamlTemplate(content string) error {
+	var template struct {
+		Title       string                            `yaml:"title"`
+		Template    *string                           `yaml:"template"`
+		Parameters  map[string]interface{}            `yaml:"parameters"`
+		Definitions map[string]map[string]interface{} `yaml:"definitions"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &template); err != nil {
+		return err
+	}
+	if template.Template == nil {
+		return fmt.Errorf("missing template property")
+	}
+	if err := validateMustache(*template.Template); err != nil {
+		return err
+	}
+	for _, name := range sortedFastDefinitionNames(template.Definitions) {
+		definition := template.Definitions[name]
+		if kind, ok := definition["type"]; ok {
+			if s, isString := kind.(string); !isString || !fastParameterTypes[s] {
+				return fmt.Errorf("definition %s has an invalid type %v", name, kind)
+			}
+		}
+		if enum, ok := definition["enum"]; ok {
+			if _, isList := enum.([]interface{}); !isList {
+				return fmt.Errorf("definition %s has an enum that is not a list", name)
+			}
+		}
+		if def, ok := template.Parameters[name]; ok && definition["enum"] != nil && !fastEnumContains(definition["enum"].([]interface{}), def) {
+			return fmt.Errorf("default value %v of parameter %s is not in its enum", def, name)
+		}
+	}
+	return nil
+}
+
+func fastEnumContains(enum []interface{}, value interface{}) bool {
+	for _, v := range enum {
+		if fmt.Sprint(v) == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// mustacheTag is a tag of a mustache template, e.g. {{#name}}, with its kind (the sigil) and its name.
+type mustacheTag struct {
+	kind byte
+	name string
+	line int
+}
+
+// parseMustache returns the tags of a mustache template, checking that tags are closed and sections
+// balanced. Set delimiter tags are followed.
+func parseMustache(text string) ([]mustacheTag, error) {
+	open, close := "{{", "}}"
+	var tags []mustacheTag
+	var sections []mustacheTag
+	for pos := 0; ; {
+		start := strings.Index(text[pos:], open)
+		if start < 0 {
+			break
+		}
+		start += pos
+		line := strings.Count(text[:start], "\n") + 1
+		body := start + len(open)
+		end := strings.Index(text[body:], close)
+		if end < 0 {
+			return nil, fmt.Errorf("line %d: unclosed tag %s", line, open)
+		}
+		content := text[body : body+end]
+		pos = body + end + len(close)
+		if open == "{{" && strings.HasPrefix(content, "{") {
+			// triple mustache {{{name}}}
+			if !strings.HasPrefix(text[pos:], "}") {
+				return nil, fmt.Errorf("line %d: unclosed tag {{{", line)
+			}
+			pos++
+			content = "&" + content[1:]
+		}
+		tag := mustacheTag{line: line}
+		content = strings.TrimSpace(content)
+		if content != "" && strings.ContainsRune("#^/!>&=", rune(content[0])) {
+			tag.kind = content[0]
+			content = strings.TrimSpace(content[1:])
+		}
+		tag.name = content
+		switch tag.kind {
+		case '!':
+			continue
+		case '=':
+			delimiters := strings.Fields(strings.TrimSuffix(content, "="))
+			if len(delimiters) != 2 {
+				return nil, fmt.Errorf("line %d: invalid set delimiter tag", line)
+			}
+			open, close = delimiters[0], delimiters[1]
+			continue
+		}
+		if tag.name == "" {
+			return nil, fmt.Errorf("line %d: empty tag", line)
+		}
+		switch tag.kind {
+		case '#', '^':
+			sections = append(sections, tag)
+		case '/':
+			if len(sections) == 0 {
+				return nil, fmt.Errorf("line %d: closing section %s was not opened", line, tag.name)
+			}
+			if opened := sections[len(sections)-1]; opened.name != tag.name {
+				return nil, fmt.Errorf("line %d: closing section %s, but section %s opened on line %d is not closed", line, tag.name, opened.name, opened.line)
+			}
+			sections = sections[:len(sections)-1]
+		}
+		tags = append(tags, tag)
+	}
+	if len(sections) > 0 {
+		opened := sections[len(sections)-1]
+		return nil, fmt.Errorf("line %d: section %s is not closed", opened.line, opened.name)
+	}
+	return tags, nil
+}
+
+// validateMustache checks the syntax of a mustache template and the types of its {{name::type}} tags.
+func validateMustache(text string) error {
+	tags, err := parseMustache(text)
+	if err != nil {
+		return err
+	}
+	for _, tag := range tags {
+		parts := strings.Split(tag.name, ":")
+		if len(parts) == 3 && parts[1] == "" && !fastParameterTypes[parts[2]] {
+			return fmt.Errorf("line %d: unknown type %s of parameter %s", tag.line, parts[2], parts[0])
+		}
+	}
+	return nil
+}
+
+// packageFastTemplateSet zips the files of a template set in memory. The archive only depends on the files,
+// so that its MD5 hash is stable.
+func packageFastTemplateSet(files map[string]string) ([]byte, error) {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range sortedFastFileNames(files) {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := f.Write([]byte(files[name])); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// fastTemplateSetHashes returns the SHA-256 hash of the files of a template set and the MD5 hash of its
+// archive.
+func fastTemplateSetHashes(files map[string]string) (string, string, error) {
+	archive, err := packageFastTemplateSet(files)
+	if err != nil {
+		return "", "", err
+	}
+	h := sha256.New()
+	for _, name := range sortedFastFileNames(files) {
+		h.Write([]byte(name))
+		h.Write([]byte{0})
+		h.Write([]byte(files[name]))
+		h.Write([]byte{0})
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), fmt.Sprintf("%x", md5.Sum(archive)), nil
+}
+
+func sortedFastFileNames(files map[string]string) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedFastDefinitionNames(definitions map[string]map[string]interface{}) []string {
+	names := make([]string, 0, len(definitions))
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMustache(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		err      string
+	}{
+		{name: "variables and sections", template: "{{! comment }}\n{{#pools}}{{name}}: {{{members}}}{{/pools}}{{^pools}}none{{/pools}}"},
+		{name: "typed parameters", template: `{"virtualPort": {{virtual_port::integer}}, "pool": {{pool:f5:pool}}}`},
+		{name: "set delimiters", template: "{{=<% %>=}}<%#a%><%b%><%/a%>"},
+		{name: "unclosed tag", template: "{\n\"a\": {{name\n}", err: "line 2: unclosed tag {{"},
+		{name: "unclosed section", template: "{{#a}}\n{{b}}", err: "line 1: section a is not closed"},
+		{name: "mismatched section", template: "{{#a}}{{#b}}{{/a}}", err: "closing section a, but section b opened on line 1 is not closed"},
+		{name: "section not opened", template: "{{/a}}", err: "closing section a was not opened"},
+		{name: "empty tag", template: "{{ }}", err: "empty tag"},
+		{name: "unknown type", template: "{{port::int}}", err: "unknown type int of parameter port"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateMustache(test.template)
+			if test.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.err)
+			}
+		})
+	}
+}
+
+func TestValidateFastTemplateSet(t *testing.T) {
+	valid := `
+title: Simple HTTP
+parameters:
+  virtual_port: 80
+  lb_mode: round-robin
+definitions:
+  virtual_port:
+    type: integer
+  lb_mode:
+    type: string
+    enum: [round-robin, least-connections-member]
+template: |
+  {"port": {{virtual_port}}, "mode": "{{lb_mode}}"}
+`
+	assert.NoError(t, validateFastTemplateSet(map[string]string{"http.yaml": valid, "README.md": "# templates"}))
+	assert.ErrorContains(t, validateFastTemplateSet(map[string]string{"README.md": "# templates"}), "no .yaml, .yml or .mst template")
+	assert.ErrorContains(t, validateFastTemplateSet(map[string]string{"http.yaml": "title: [unclosed"}), "invalid template http.yaml")
+	assert.ErrorContains(t, validateFastTemplateSet(map[string]string{"http.yaml": "title: no template"}), "missing template property")
+	assert.ErrorContains(t, validateFastTemplateSet(map[string]string{"http.yaml": "definitions:\n  port:\n    type: port\ntemplate: x"}), "definition port has an invalid type port")
+	assert.ErrorContains(t, validateFastTemplateSet(map[string]string{"http.yaml": "parameters:\n  mode: fastest\ndefinitions:\n  mode:\n    enum: [a, b]\ntemplate: x"}), "default value fastest of parameter mode is not in its enum")
+	assert.ErrorContains(t, validateFastTemplateSet(map[string]string{"a.mst": "{{x}}", "schema.json": "{"}), "invalid template schema.json")
+}
+
+func TestPackageFastTemplateSet(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "partials"), 0700))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "http.mst"), []byte("{{> partials/pool}}"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "partials", "pool.mst"), []byte("{{pool}}"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.zip"), 0600))
+
+	files, err := readFastTemplateDirectory(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"http.mst": "{{> partials/pool}}", "partials/pool.mst": "{{pool}}"}, files)
+
+	archive, err := packageFastTemplateSet(files)
+	assert.NoError(t, err)
+	r, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	assert.NoError(t, err)
+	assert.Len(t, r.File, 2)
+	assert.Equal(t, "http.mst", r.File[0].Name)
+	f, err := r.File[1].Open()
+	assert.NoError(t, err)
+	content, _ := io.ReadAll(f)
+	assert.Equal(t, "{{pool}}", string(content))
+
+	contentHash, md5Hash, err := fastTemplateSetHashes(files)
+	assert.NoError(t, err)
+	againContent, againMd5, _ := fastTemplateSetHashes(map[string]string{"partials/pool.mst": "{{pool}}", "http.mst": "{{> partials/pool}}"})
+	assert.Equal(t, contentHash, againContent)
+	assert.Equal(t, md5Hash, againMd5)
+	changed, _, _ := fastTemplateSetHashes(map[string]string{"partials/pool.mst": "{{pools}}", "http.mst": "{{> partials/pool}}"})
+	assert.NotEqual(t, contentHash, changed)
+}
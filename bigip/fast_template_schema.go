@@ -0,0 +1,238 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+const fastTemplatesURL = "/mgmt/shared/fast/templates"
+
+// fastTemplateSchema is the parameter schema FAST publishes for a template.
+type fastTemplateSchema struct {
+	title       string
+	description string
+	raw         map[string]interface{}
+	schema      *jsonschema.Schema
+}
+
+// fastTemplateParameter is a parameter of a FAST template, read from its schema.
+type fastTemplateParameter struct {
+	name        string
+	kind        string
+	description string
+	defaultJSON string
+	required    bool
+	enum        []string
+}
+
+// errFastTemplateNotFound is returned for templates FAST does not know.
+var errFastTemplateNotFound = errors.New("template not found")
+
+// fastTemplateSchemas caches the template schemas by BIG-IP and template for the provider run, as a plan
+// validates every application of a template. The schemas of a template set are dropped when it is uploaded
+// or deleted.
+var fastTemplateSchemas = struct {
+	sync.Mutex
+	schemas map[string]*fastTemplateSchema
+}{schemas: make(map[string]*fastTemplateSchema)}
+
+// getFastTemplateSchema returns the schema of a template, named set/template.
+func getFastTemplateSchema(client *bigip.BigIP, template string) (*fastTemplateSchema, error) {
+	key := client.Host + "/" + template
+	fastTemplateSchemas.Lock()
+	s, ok := fastTemplateSchemas.schemas[key]
+	fastTemplateSchemas.Unlock()
+	if ok {
+		return s, nil
+	}
+	// the schema is fetched without holding the lock, so that plans of other templates and hosts do not wait
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "get",
+		URL:         fastTemplatesURL + "/" + template,
+		ContentType: "application/json",
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "404") || strings.Contains(strings.ToLower(err.Error()), "not found") {
+			return nil, fmt.Errorf("error reading FAST template (%s): %w", template, errFastTemplateNotFound)
+		}
+		return nil, fmt.Errorf("error reading FAST template (%s): %v", template, err)
+	}
+	s, err = parseFastTemplateSchema(resp)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing FAST template (%s): %v", template, err)
+	}
+	fastTemplateSchemas.Lock()
+	fastTemplateSchemas.schemas[key] = s
+	fastTemplateSchemas.Unlock()
+	return s, nil
+}
+
+// invalidateFastTemplateSchemas drops the cached schemas of the templates of a template set.
+func invalidateFastTemplateSchemas(client *bigip.BigIP, set string) {
+	prefix := client.Host + "/" + set + "/"
+	fastTemplateSchemas.Lock()
+	defer fastTemplateSchemas.Unlock()
+	for key := range fastTemplateSchemas.schemas {
+		if strings.HasPrefix(key, prefix) {
+			delete(fastTemplateSchemas.schemas, key)
+		}
+	}
+}
+
+func parseFastTemplateSchema(resp []byte) (*fastTemplateSchema, error) {
+	var template struct {
+		Title            string                 `json:"title"`
+		Description      string                 `json:"description"`
+		ParametersSchema map[string]interface{} `json:"_parametersSchema"`
+	}
+	if err := json.Unmarshal(resp, &template); err != nil {
+		return nil, err
+	}
+	if template.ParametersSchema == nil {
+		return nil, fmt.Errorf("no parameter schema in the template")
+	}
+	out, _ := json.Marshal(template.ParametersSchema)
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+	if err := compiler.AddResource("parameters.json", strings.NewReader(string(out))); err != nil {
+		return nil, err
+	}
+	compiled, err := compiler.Compile("parameters.json")
+	if err != nil {
+		return nil, err
+	}
+	return &fastTemplateSchema{
+		title:       template.Title,
+		description: template.Description,
+		raw:         template.ParametersSchema,
+		schema:      compiled,
+	}, nil
+}
+
+// validate returns the problems of the parameters, one per offending parameter.
+func (s *fastTemplateSchema) validate(fastJson string) ([]string, error) {
+	var params interface{}
+	decoder := json.NewDecoder(strings.NewReader(fastJson))
+	decoder.UseNumber()
+	if err := decoder.Decode(&params); err != nil {
+		return nil, err
+	}
+	err := s.schema.Validate(params)
+	if err == nil {
+		return nil, nil
+	}
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil, err
+	}
+	var problems []string
+	seen := make(map[string]bool)
+	for _, cause := range declarationSchemaErrors(validationErr) {
+		location := cause.InstanceLocation
+		if location == "" {
+			location = "/"
+		}
+		problem := fmt.Sprintf("%s: %s", location, cause.Message)
+		if !seen[problem] {
+			seen[problem] = true
+			problems = append(problems, problem)
+		}
+	}
+	sort.Strings(problems)
+	return problems, nil
+}
+
+// parameters returns the parameters of the template sorted by name.
+func (s *fastTemplateSchema) parameters() []fastTemplateParameter {
+	required := make(map[string]bool)
+	if list, ok := s.raw["required"].([]interface{}); ok {
+		for _, name := range list {
+			required[fmt.Sprint(name)] = true
+		}
+	}
+	properties, _ := s.raw["properties"].(map[string]interface{})
+	var parameters []fastTemplateParameter
+	for name, value := range properties {
+		property, _ := value.(map[string]interface{})
+		parameter := fastTemplateParameter{name: name, required: required[name]}
+		parameter.kind, _ = property["type"].(string)
+		parameter.description, _ = property["description"].(string)
+		if parameter.description == "" {
+			parameter.description, _ = property["title"].(string)
+		}
+		if def, ok := property["default"]; ok {
+			out, _ := json.Marshal(def)
+			parameter.defaultJSON = string(out)
+		}
+		if enum, ok := property["enum"].([]interface{}); ok {
+			for _, v := range enum {
+				parameter.enum = append(parameter.enum, fmt.Sprint(v))
+			}
+		}
+		parameters = append(parameters, parameter)
+	}
+	sort.Slice(parameters, func(i, j int) bool { return parameters[i].name < parameters[j].name })
+	return parameters
+}
+
+// validateFastApplicationParams validates the parameters of an application against the schema of its
+// template, and returns a diagnostic at path for each problem. Templates FAST does not know yet, e.g.
+// installed in the same apply, are not validated, and other failures to read the schema are reported.
+func validateFastApplicationParams(client *bigip.BigIP, template, fastJson string, path cty.Path) diag.Diagnostics {
+	s, err := getFastTemplateSchema(client, template)
+	if errors.Is(err, errFastTemplateNotFound) {
+		log.Printf("[WARN] FAST template %s is not installed yet, skipping the validation of its parameters", template)
+		return nil
+	}
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("Unable to validate fast_json against the parameters of FAST template %s", template),
+			Detail:        err.Error(),
+			AttributePath: path,
+		}}
+	}
+	problems, err := s.validate(fastJson)
+	if err != nil {
+		// invalid JSON is reported when posting the application
+		return nil
+	}
+	var diags diag.Diagnostics
+	for _, problem := range problems {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("fast_json does not match the parameters of FAST template %s", template),
+			Detail:        problem,
+			AttributePath: path,
+		})
+	}
+	return diags
+}
+
+// fastApplicationParamsError returns the diagnostics of validateFastApplicationParams as the single error a
+// CustomizeDiff can return, attached to path.
+func fastApplicationParamsError(diags diag.Diagnostics, path cty.Path) error {
+	if !diags.HasError() {
+		return nil
+	}
+	details := make([]string, 0, len(diags))
+	for _, d := range diags {
+		details = append(details, d.Detail)
+	}
+	return path.NewErrorf("%s:\n  %s", diags[0].Summary, strings.Join(details, "\n  "))
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+const fastTemplateSimpleHttp = `{
+	"title": "Simple HTTP Application",
+	"description": "A basic HTTP application",
+	"_parametersSchema": {
+		"type": "object",
+		"properties": {
+			"tenant_name": {"type": "string", "title": "Tenant Name"},
+			"application_name": {"type": "string", "title": "Application Name"},
+			"virtual_port": {"type": "integer", "description": "Virtual Server Port", "default": 443},
+			"load_balancing_mode": {"type": "string", "default": "round-robin", "enum": ["round-robin", "least-connections-member"]}
+		},
+		"required": ["tenant_name", "application_name"]
+	}
+}`
+
+func TestFastTemplateSchema(t *testing.T) {
+	setup()
+	defer teardown()
+	requests := 0
+	mux.HandleFunc("/mgmt/shared/fast/templates/examples/simple_http", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, fastTemplateSimpleHttp)
+	})
+	mux.HandleFunc("/mgmt/shared/fast/templates/examples/missing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = fmt.Fprint(w, `{"code": 404, "message": "template not found"}`)
+	})
+	mux.HandleFunc("/mgmt/shared/fast/templates/examples/broken", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		_, _ = fmt.Fprint(w, `{"code": 500, "message": "FAST is not ready"}`)
+	})
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	s, err := getFastTemplateSchema(client, "examples/simple_http")
+	assert.NoError(t, err)
+	_, err = getFastTemplateSchema(client, "examples/simple_http")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests, "the schema is cached")
+
+	// uploading a template set drops the schemas of its templates only
+	invalidateFastTemplateSchemas(client, "bigip-fast-templates")
+	_, err = getFastTemplateSchema(client, "examples/simple_http")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, requests)
+	invalidateFastTemplateSchemas(client, "examples")
+	_, err = getFastTemplateSchema(client, "examples/simple_http")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests, "the schema is read again")
+
+	problems, err := s.validate(`{"tenant_name": "t1", "application_name": "app1", "virtual_port": 80}`)
+	assert.NoError(t, err)
+	assert.Empty(t, problems)
+
+	problems, err = s.validate(`{"tenant_name": "t1", "virtual_port": "80", "load_balancing_mode": "ratio"}`)
+	assert.NoError(t, err)
+	assert.Len(t, problems, 3)
+	assert.Contains(t, problems[0], "/: missing properties: 'application_name'")
+	assert.Contains(t, problems[1], "/load_balancing_mode:")
+	assert.Contains(t, problems[2], "/virtual_port: expected integer")
+
+	path := cty.GetAttrPath("fast_json")
+	diags := validateFastApplicationParams(client, "examples/simple_http", `{"tenant_name": "t1", "virtual_port": "80"}`, path)
+	if assert.Len(t, diags, 2) {
+		for _, d := range diags {
+			assert.Equal(t, diag.Error, d.Severity)
+			assert.Equal(t, "fast_json does not match the parameters of FAST template examples/simple_http", d.Summary)
+			assert.Equal(t, path, d.AttributePath)
+		}
+		assert.Contains(t, diags[0].Detail, "/: missing properties: 'application_name'")
+		assert.Contains(t, diags[1].Detail, "/virtual_port: expected integer")
+	}
+	err = fastApplicationParamsError(diags, path)
+	var pathErr cty.PathError
+	if assert.ErrorAs(t, err, &pathErr) {
+		assert.Equal(t, path, pathErr.Path)
+		assert.Contains(t, err.Error(), "/virtual_port: expected integer")
+	}
+	assert.Empty(t, validateFastApplicationParams(client, "examples/missing", `{"tenant_name": "t1"}`, path), "templates FAST does not know are not validated")
+	diags = validateFastApplicationParams(client, "examples/broken", `{"tenant_name": "t1"}`, path)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Unable to validate fast_json against the parameters of FAST template examples/broken", diags[0].Summary)
+		assert.Contains(t, diags[0].Detail, "FAST is not ready")
+		assert.Equal(t, path, diags[0].AttributePath)
+	}
+
+	parameters := s.parameters()
+	assert.Len(t, parameters, 4)
+	assert.Equal(t, fastTemplateParameter{name: "application_name", kind: "string", description: "Application Name", required: true}, parameters[0])
+	assert.Equal(t, []string{"round-robin", "least-connections-member"}, parameters[1].enum)
+	assert.Equal(t, `"round-robin"`, parameters[1].defaultJSON)
+	assert.Equal(t, "443", parameters[3].defaultJSON)
+}
+
+func TestFastTemplateSchemaConcurrentFetch(t *testing.T) {
+	setup()
+	defer teardown()
+	release := make(chan struct{})
+	mux.HandleFunc("/mgmt/shared/fast/templates/examples/slow", func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		_, _ = fmt.Fprint(w, fastTemplateSimpleHttp)
+	})
+	mux.HandleFunc("/mgmt/shared/fast/templates/examples/simple_http", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, fastTemplateSimpleHttp)
+	})
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	slow := make(chan error)
+	go func() {
+		_, err := getFastTemplateSchema(client, "examples/slow")
+		slow <- err
+	}()
+	// the schema of another template is fetched while the slow one is pending
+	fetched := make(chan error)
+	go func() {
+		_, err := getFastTemplateSchema(client, "examples/simple_http")
+		fetched <- err
+	}()
+	select {
+	case err := <-fetched:
+		assert.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		close(release)
+		t.Fatal("the schema fetch waited for another template")
+	}
+	close(release)
+	assert.NoError(t, <-slow)
+}
+
+func TestDataSourceBigipFastTemplateInfoRead(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/shared/fast/templates/examples/simple_http", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, fastTemplateSimpleHttp)
+	})
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	d := schema.TestResourceDataRaw(t, dataSourceBigipFastTemplateInfo().Schema, map[string]interface{}{"name": "examples/simple_http"})
+	diags := dataSourceBigipFastTemplateInfoRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "Simple HTTP Application", d.Get("title"))
+	assert.Equal(t, 4, d.Get("parameters.#"))
+	assert.Equal(t, "tenant_name", d.Get("parameters.2.name"))
+	assert.Equal(t, true, d.Get("parameters.2.required"))
+	assert.Equal(t, "least-connections-member", d.Get("parameters.1.enum.1"))
+	assert.Contains(t, d.Get("parameters_schema"), `"required":["tenant_name","application_name"]`)
+}
@@ -0,0 +1,698 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// iruleFinding is a single problem found in an iRule body.
+type iruleFinding struct {
+	line     int
+	severity diag.Severity
+	message  string
+	// advisory warnings come from incomplete tables, such as the commands of a namespace, and do not
+	// fail the plan in strict mode
+	advisory bool
+}
+
+func (f iruleFinding) String() string {
+	return fmt.Sprintf("line %d: %s", f.line, f.message)
+}
+
+// tclWord is a word of a Tcl command. For braced and quoted words text holds the content
+// without the enclosing delimiters.
+type tclWord struct {
+	text   string
+	braced bool
+	line   int
+}
+
+type tclCommand struct {
+	words []tclWord
+	line  int
+}
+
+// tclParser splits a Tcl script into commands and words, following the Tcl quoting rules
+// closely enough to report the same brace, quote and bracket errors TMOS does.
+type tclParser struct {
+	src      string
+	pos      int
+	line     int
+	findings []iruleFinding
+}
+
+func newTclParser(src string, line int) *tclParser {
+	return &tclParser{src: src, line: line}
+}
+
+func (p *tclParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *tclParser) peek() byte {
+	return p.src[p.pos]
+}
+
+func (p *tclParser) next() byte {
+	c := p.src[p.pos]
+	p.pos++
+	if c == '\n' {
+		p.line++
+	}
+	return c
+}
+
+func (p *tclParser) errorf(line int, format string, a ...interface{}) {
+	p.findings = append(p.findings, iruleFinding{line: line, severity: diag.Error, message: fmt.Sprintf(format, a...)})
+}
+
+// skipSpace skips blanks and backslash-newline continuations, and also newlines and
+// semicolons when terminators is set.
+func (p *tclParser) skipSpace(terminators bool) {
+	for !p.eof() {
+		c := p.peek()
+		switch {
+		case c == ' ' || c == '\t' || c == '\r':
+			p.next()
+		case c == '\\' && p.pos+1 < len(p.src) && p.src[p.pos+1] == '\n':
+			p.next()
+			p.next()
+		case terminators && (c == '\n' || c == ';'):
+			p.next()
+		default:
+			return
+		}
+	}
+}
+
+func (p *tclParser) skipComment() {
+	for !p.eof() {
+		c := p.next()
+		if c == '\\' && !p.eof() {
+			p.next()
+			continue
+		}
+		if c == '\n' {
+			return
+		}
+	}
+}
+
+// parse returns the commands of the script. Parsing stops at the first fatal syntax error.
+func (p *tclParser) parse() []tclCommand {
+	var commands []tclCommand
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return commands
+		}
+		if p.peek() == '#' {
+			p.skipComment()
+			continue
+		}
+		command := tclCommand{line: p.line}
+		for {
+			p.skipSpace(false)
+			if p.eof() || p.peek() == '\n' || p.peek() == ';' {
+				break
+			}
+			word, ok := p.parseWord()
+			if !ok {
+				return commands
+			}
+			command.words = append(command.words, word)
+		}
+		if len(command.words) > 0 {
+			commands = append(commands, command)
+		}
+	}
+}
+
+// parseList returns the elements of a Tcl list, where newlines are plain separators.
+func (p *tclParser) parseList() []tclWord {
+	var words []tclWord
+	for {
+		p.skipSpace(true)
+		if p.eof() {
+			return words
+		}
+		word, ok := p.parseWord()
+		if !ok {
+			return words
+		}
+		words = append(words, word)
+	}
+}
+
+func (p *tclParser) atWordEnd() bool {
+	if p.eof() {
+		return true
+	}
+	switch p.peek() {
+	case ' ', '\t', '\r', '\n', ';':
+		return true
+	case '\\':
+		return p.pos+1 < len(p.src) && p.src[p.pos+1] == '\n'
+	}
+	return false
+}
+
+func (p *tclParser) parseWord() (tclWord, bool) {
+	line := p.line
+	switch p.peek() {
+	case '{':
+		p.next()
+		start := p.pos
+		if !p.skipBraced(line) {
+			return tclWord{}, false
+		}
+		word := tclWord{text: p.src[start : p.pos-1], braced: true, line: line}
+		if !p.atWordEnd() {
+			p.errorf(p.line, "extra characters after close-brace")
+			return tclWord{}, false
+		}
+		return word, true
+	case '"':
+		p.next()
+		start := p.pos
+		if !p.skipQuoted(line) {
+			return tclWord{}, false
+		}
+		word := tclWord{text: p.src[start : p.pos-1], line: line}
+		if !p.atWordEnd() {
+			p.errorf(p.line, "extra characters after close-quote")
+			return tclWord{}, false
+		}
+		return word, true
+	case '}':
+		p.errorf(line, "unmatched close-brace")
+		return tclWord{}, false
+	}
+	start := p.pos
+	for !p.atWordEnd() {
+		c := p.next()
+		switch c {
+		case '\\':
+			if !p.eof() {
+				p.next()
+			}
+		case '[':
+			if !p.skipBracketed(line) {
+				return tclWord{}, false
+			}
+		}
+	}
+	return tclWord{text: p.src[start:p.pos], line: line}, true
+}
+
+// skipBraced advances past the brace matching an already consumed open brace.
+func (p *tclParser) skipBraced(line int) bool {
+	depth := 1
+	for !p.eof() {
+		switch p.next() {
+		case '\\':
+			if !p.eof() {
+				p.next()
+			}
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return true
+			}
+		}
+	}
+	p.errorf(line, "missing close-brace for the open brace on this line")
+	return false
+}
+
+// skipQuoted advances past the quote matching an already consumed open quote.
+func (p *tclParser) skipQuoted(line int) bool {
+	for !p.eof() {
+		switch p.next() {
+		case '\\':
+			if !p.eof() {
+				p.next()
+			}
+		case '[':
+			if !p.skipBracketed(p.line) {
+				return false
+			}
+		case '"':
+			return true
+		}
+	}
+	p.errorf(line, "missing close-quote for the open quote on this line")
+	return false
+}
+
+// skipBracketed advances past the bracket matching an already consumed open bracket.
+func (p *tclParser) skipBracketed(line int) bool {
+	for !p.eof() {
+		c := p.next()
+		switch c {
+		case '\\':
+			if !p.eof() {
+				p.next()
+			}
+		case '[':
+			if !p.skipBracketed(p.line) {
+				return false
+			}
+		case ']':
+			return true
+		case '{':
+			if !p.skipBraced(p.line) {
+				return false
+			}
+		case '"':
+			if !p.skipQuoted(p.line) {
+				return false
+			}
+		}
+	}
+	p.errorf(line, "missing close-bracket for the open bracket on this line")
+	return false
+}
+
+// tclSubstitutions returns the command substitutions found at the top level of text.
+func tclSubstitutions(text string, line int) []tclWord {
+	var subs []tclWord
+	depth := 0
+	start, startLine := 0, line
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\n':
+			line++
+		case '\\':
+			i++
+			if i < len(text) && text[i] == '\n' {
+				line++
+			}
+		case '[':
+			if depth == 0 {
+				start, startLine = i+1, line
+			}
+			depth++
+		case ']':
+			if depth > 0 {
+				depth--
+				if depth == 0 {
+					subs = append(subs, tclWord{text: text[start:i], line: startLine})
+				}
+			}
+		}
+	}
+	return subs
+}
+
+var iruleEvents = makeLookup([]string{
+	"RULE_INIT", "CLIENT_ACCEPTED", "CLIENT_CLOSED", "CLIENT_DATA", "SERVER_INIT", "SERVER_CONNECTED",
+	"SERVER_CLOSED", "SERVER_DATA", "LB_SELECTED", "LB_FAILED", "LB_QUEUED", "PERSIST_DOWN", "FLOW_INIT",
+	"HTTP_REQUEST", "HTTP_REQUEST_DATA", "HTTP_REQUEST_SEND", "HTTP_REQUEST_RELEASE", "HTTP_RESPONSE",
+	"HTTP_RESPONSE_DATA", "HTTP_RESPONSE_CONTINUE", "HTTP_RESPONSE_RELEASE", "HTTP_CLASS_SELECTED",
+	"HTTP_CLASS_FAILED", "HTTP_DISABLED", "HTTP_REJECT", "HTTP_PROXY_REQUEST", "HTTP_PROXY_CONNECT",
+	"HTTP_PROXY_RESPONSE", "CLIENTSSL_HANDSHAKE", "CLIENTSSL_CLIENTCERT", "CLIENTSSL_CLIENTHELLO",
+	"CLIENTSSL_SERVERHELLO_SEND", "CLIENTSSL_DATA", "CLIENTSSL_PASSTHROUGH", "SERVERSSL_HANDSHAKE",
+	"SERVERSSL_CLIENTHELLO_SEND", "SERVERSSL_SERVERHELLO", "SERVERSSL_SERVERCERT", "SERVERSSL_DATA",
+	"CACHE_REQUEST", "CACHE_RESPONSE", "CACHE_UPDATE", "DNS_REQUEST", "DNS_RESPONSE", "NAME_RESOLVED",
+	"ACCESS_SESSION_STARTED", "ACCESS_SESSION_CLOSED", "ACCESS_POLICY_AGENT_EVENT", "ACCESS_POLICY_COMPLETED",
+	"ACCESS_ACL_ALLOWED", "ACCESS_ACL_DENIED", "ACCESS_PER_REQUEST_AGENT_EVENT", "ACCESS2_POLICY_EXPRESSION_EVAL",
+	"ASM_REQUEST_DONE", "ASM_REQUEST_BLOCKING", "ASM_REQUEST_VIOLATION", "ASM_RESPONSE_VIOLATION",
+	"ASM_RESPONSE_LOGIN", "AUTH_RESULT", "AUTH_ERROR", "AUTH_FAILURE", "AUTH_SUCCESS", "AUTH_WANTCREDENTIAL",
+	"STREAM_MATCHED", "HTML_TAG_MATCHED", "HTML_COMMENT_MATCHED", "REWRITE_REQUEST_DONE", "REWRITE_RESPONSE_DONE",
+	"ADAPT_REQUEST_RESULT", "ADAPT_RESPONSE_RESULT", "ADAPT_REQUEST_HEADERS", "ADAPT_RESPONSE_HEADERS",
+	"ICAP_REQUEST", "ICAP_RESPONSE", "SIP_REQUEST", "SIP_RESPONSE", "SIP_REQUEST_SEND", "SIP_RESPONSE_SEND",
+	"WS_REQUEST", "WS_RESPONSE", "WS_CLIENT_FRAME", "WS_SERVER_FRAME", "WS_CLIENT_DATA", "WS_SERVER_DATA",
+	"WS_CLIENT_FRAME_DONE", "WS_SERVER_FRAME_DONE", "XML_CONTENT_BASED_ROUTING", "XML_BEGIN_DOCUMENT",
+	"XML_BEGIN_ELEMENT", "XML_CDATA", "XML_END_DOCUMENT", "XML_END_ELEMENT", "XML_EVENT", "IN_DOSL7_ATTACK",
+	"BOTDEFENSE_REQUEST", "BOTDEFENSE_ACTION", "ANTIFRAUD_LOGIN", "ANTIFRAUD_ALERT", "CATEGORY_MATCHED",
+	"CLASSIFICATION_DETECTED", "PCP_REQUEST", "PCP_RESPONSE", "QOE_PARSE_DONE", "SA_PICKED", "MR_INGRESS",
+	"MR_EGRESS", "MR_FAILED", "GENERICMESSAGE_INGRESS", "GENERICMESSAGE_EGRESS", "MQTT_CLIENT_INGRESS",
+	"MQTT_CLIENT_EGRESS", "MQTT_SERVER_INGRESS", "MQTT_SERVER_EGRESS", "MQTT_CLIENT_DATA", "MQTT_SERVER_DATA",
+	"RTSP_REQUEST", "RTSP_RESPONSE", "RTSP_REQUEST_DATA", "RTSP_RESPONSE_DATA", "DIAMETER_INGRESS",
+	"DIAMETER_EGRESS", "PEM_POLICY", "PEM_SUBS_SESS_CREATED", "PEM_SUBS_SESS_DELETED", "PEM_SUBS_SESS_UPDATED",
+	"ECA_REQUEST_ALLOWED", "USER_REQUEST", "USER_RESPONSE", "GTP_SIGNALLING_INGRESS", "GTP_SIGNALLING_EGRESS",
+	"GTP_GPDU_INGRESS", "GTP_GPDU_EGRESS", "FIX_MESSAGE", "PROTOCOL_INSPECTION_MATCH", "TAP_REQUEST",
+	"L7CHECK_CLIENT_DATA", "L7CHECK_SERVER_DATA", "SOCKS_REQUEST", "TDS_REQUEST", "TDS_RESPONSE",
+	"IVS_ENTRYPOINT_REQUEST", "IVS_ENTRYPOINT_RESPONSE", "SCTP_DATA", "PING_REQUEST_READY", "PING_RESPONSE_READY",
+	"RADIUS_AAA_ACCT_REQUEST", "RADIUS_AAA_ACCT_RESPONSE", "RADIUS_AAA_AUTH_REQUEST", "RADIUS_AAA_AUTH_RESPONSE",
+	"API_PROTECTION_REQUEST", "SSO_ENTRYPOINT",
+})
+
+var iruleCommands = makeLookup([]string{
+	// Tcl commands available in iRules
+	"append", "array", "binary", "break", "catch", "clock", "concat", "continue", "error", "eval", "expr",
+	"for", "foreach", "format", "global", "if", "incr", "info", "join", "lappend", "lassign", "lindex",
+	"linsert", "list", "llength", "lrange", "lrepeat", "lreplace", "lreverse", "lsearch", "lset", "lsort",
+	"namespace", "regexp", "regsub", "rename", "return", "scan", "set", "split", "string", "subst", "switch",
+	"unset", "uplevel", "upvar", "variable", "while", "dict", "apply", "tailcall",
+	// iRule commands
+	"active_members", "active_nodelist", "after", "b64decode", "b64encode", "call", "class", "clientside",
+	"clone", "close", "connect", "cpu", "crc32", "discard", "domain", "drop", "event", "findstr", "forward",
+	"getfield", "htonl", "htons", "lasthop", "listen", "log", "md4", "md5", "members", "nexthop", "node",
+	"ntohl", "ntohs", "peer", "persist", "pool", "priority", "rateclass", "recv", "reject", "relate_client",
+	"relate_server", "send", "serverside", "session", "sha1", "sha256", "sha384", "sha512", "sharedvar",
+	"snat", "snatpool", "substr", "table", "timing", "traffic_group", "virtual", "whereis",
+})
+
+var iruleNamespaces = makeLookup([]string{
+	"AES", "ACCESS", "ACCESS2", "ACL", "ADAPT", "ANTIFRAUD", "API_PROTECTION", "ASM", "ASN1", "AUTH", "AVR",
+	"BOTDEFENSE", "BWC", "CACHE", "CATEGORY", "CLASSIFICATION", "CLASSIFY", "COMPRESS", "CONNECTOR", "CRYPTO",
+	"DATAGRAM", "DHCP", "DHCPv4", "DHCPv6", "DIAMETER", "DNS", "DNSMSG", "DOSL7", "DSLITE", "ECA", "FIX",
+	"FLOW", "FTP", "GENERICMESSAGE", "GTP", "HA", "HSL", "HTML", "HTTP", "HTTP2", "HTTP3", "ICAP", "IKE",
+	"ILX", "IMAP", "IP", "IPFIX", "ISESSION", "ISTATS", "IVS_ENTRYPOINT", "L7CHECK", "LB", "LDAP", "LINE",
+	"LINK", "LSN", "MESSAGE", "MQTT", "MR", "NAME", "NSH", "NTLM", "ONECONNECT", "PCP", "PEM", "PLUGIN",
+	"POLICY", "POP3", "PROFILE", "PSC", "PSM", "QOE", "QUIC", "RADIUS", "RESOLV", "RESOLVER", "REST",
+	"REWRITE", "ROUTE", "RTSP", "SCTP", "SDP", "SIP", "SIPALG", "SMTPS", "SOCKS", "SPDY", "SSE", "SSL",
+	"STATS", "STREAM", "TAP", "TCP", "TDS", "TMM", "UDP", "URI", "VALIDATE", "VDI", "WAM", "WEBSSO", "WS",
+	"X509", "XLAT", "XML",
+})
+
+// iruleNamespaceCommands lists the commands of the most used namespaces, commands of other
+// namespaces are only checked for a known namespace. The lists are not exhaustive, so commands
+// missing from them are reported as advisory warnings.
+var iruleNamespaceCommands = map[string]map[string]bool{
+	"HTTP": makeLookup([]string{
+		"class", "close", "collect", "cookie", "disable", "enable", "fallback", "has_responded", "header",
+		"host", "hsts", "is_keepalive", "is_redirect", "method", "passthrough_reason", "password", "path",
+		"payload", "proxy", "query", "redirect", "release", "reject_reason", "request", "request_num",
+		"respond", "retry", "status", "uri", "username", "version",
+	}),
+	"IP": makeLookup([]string{
+		"addr", "client_addr", "hops", "idle_timeout", "intelligence", "local_addr", "protocol",
+		"remote_addr", "reputation", "server_addr", "stats", "tos", "ttl", "version",
+	}),
+	"TCP": makeLookup([]string{
+		"abc", "analytics", "autowin", "bandwidth", "client_port", "close", "collect", "congestion",
+		"delayed_ack", "dsack", "earlyrxmit", "ecn", "enhanced_loss_recovery", "idletime", "keepalive",
+		"limxmit", "local_port", "mss", "nagle", "notify", "offset", "option", "pacing", "payload",
+		"proxybuffer", "proxybufferhigh", "proxybufferlow", "push_flag", "rcv_scale", "rcv_size",
+		"recvwnd", "release", "remote_port", "respond", "rt_metrics_timeout", "rtt", "sendbuf", "server_port",
+		"setmss", "snd_cwnd", "snd_scale", "snd_ssthresh", "snd_wnd", "unused_port",
+	}),
+	"LB": makeLookup([]string{
+		"bias", "class", "command", "connect", "context_id", "detach", "down", "dst_tag", "enable_decisionlog",
+		"mode", "persist", "prime", "queue", "reselect", "select", "server", "snat", "src_tag", "status", "up",
+	}),
+	"SSL": makeLookup([]string{
+		"alert", "allow_dynamic_record_sizing", "allow_nonssl", "authenticate", "c3d", "cert", "cipher",
+		"clientrandom", "collect", "disable", "enable", "extensions", "forward_proxy", "handshake",
+		"is_renegotiation_secure", "mode", "modssl_sessionid_headers", "payload", "profile", "release",
+		"renegotiate", "respond", "secure_renegotiation", "session", "sessionid", "sessionsecret",
+		"sessionticket", "sni", "tls13_secret", "unclean_shutdown", "verify_result",
+	}),
+	"URI": makeLookup([]string{
+		"basename", "compare", "decode", "encode", "host", "path", "port", "protocol", "query",
+	}),
+}
+
+// iruleDeprecatedCommands maps commands deprecated since BIG-IP 9.x/10.x to their replacement.
+var iruleDeprecatedCommands = map[string]string{
+	"accumulate":   "TCP::collect",
+	"client_addr":  "IP::client_addr",
+	"client_port":  "TCP::client_port",
+	"decode_uri":   "URI::decode",
+	"findclass":    "class search",
+	"http_cookie":  "HTTP::cookie",
+	"http_header":  "HTTP::header",
+	"http_host":    "HTTP::host",
+	"http_method":  "HTTP::method",
+	"http_uri":     "HTTP::uri",
+	"http_version": "HTTP::version",
+	"imid":         "",
+	"ip_protocol":  "IP::protocol",
+	"ip_tos":       "IP::tos",
+	"ip_ttl":       "IP::ttl",
+	"link_qos":     "LINK::qos",
+	"local_addr":   "IP::local_addr",
+	"matchclass":   "class match",
+	"redirect":     "HTTP::redirect",
+	"remote_addr":  "IP::remote_addr",
+	"server_addr":  "IP::server_addr",
+	"server_port":  "TCP::server_port",
+	"use":          "pool, node or snat directly",
+	"vlan_id":      "LINK::vlan_id",
+}
+
+// iruleConnectionNamespaces and iruleConnectionCommands need a connection and cannot be used in RULE_INIT.
+var iruleConnectionNamespaces = makeLookup([]string{
+	"ACCESS", "ASM", "DNS", "HTTP", "HTTP2", "IP", "LB", "LINK", "SIP", "SSL", "TCP", "UDP",
+})
+
+var iruleConnectionCommands = makeLookup([]string{
+	"clientside", "discard", "drop", "forward", "node", "persist", "pool", "reject", "serverside", "session",
+	"snat", "snatpool", "table", "virtual",
+})
+
+// iruleHTTPPreRequestEvents run before any HTTP request has been parsed.
+var iruleHTTPPreRequestEvents = makeLookup([]string{"CLIENT_ACCEPTED", "CLIENT_DATA", "FLOW_INIT"})
+
+var iruleHTTPPreRequestCommands = makeLookup([]string{"HTTP::disable", "HTTP::enable", "HTTP::class"})
+
+func makeLookup(values []string) map[string]bool {
+	lookup := make(map[string]bool, len(values))
+	for _, v := range values {
+		lookup[v] = true
+	}
+	return lookup
+}
+
+// iruleVisitor is called for every command of an iRule together with the event it runs in.
+type iruleVisitor func(command tclCommand, event string) []iruleFinding
+
+// lintIRule checks an iRule body for syntax errors, unknown events and commands, commands used
+// outside of the events they are valid in, and deprecated commands.
+func lintIRule(body string) []iruleFinding {
+	return walkIRule(body, lintIRuleCommand)
+}
+
+// walkIRule parses an iRule body and calls visit for every command of its events and procs,
+// including the commands nested in control structures and command substitutions.
+func walkIRule(body string, visit iruleVisitor) []iruleFinding {
+	p := newTclParser(body, 1)
+	commands := p.parse()
+	findings := p.findings
+	for _, command := range commands {
+		name := command.words[0].text
+		switch name {
+		case "when":
+			findings = append(findings, walkIRuleEvent(command, visit)...)
+		case "proc":
+			if len(command.words) != 4 {
+				findings = append(findings, iruleFinding{line: command.line, severity: diag.Error, message: "proc requires a name, an argument list and a body"})
+				continue
+			}
+			findings = append(findings, walkIRuleScript(command.words[3], "", visit)...)
+		case "priority", "timing", "nodelete":
+		default:
+			findings = append(findings, iruleFinding{line: command.line, severity: diag.Error, message: fmt.Sprintf("unexpected command %q outside of an event, expected \"when\" or \"proc\"", name)})
+		}
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].line < findings[j].line
+	})
+	return findings
+}
+
+func walkIRuleEvent(command tclCommand, visit iruleVisitor) []iruleFinding {
+	var findings []iruleFinding
+	words := command.words
+	if len(words) < 3 {
+		return append(findings, iruleFinding{line: command.line, severity: diag.Error, message: "when requires an event name and a body"})
+	}
+	event := words[1].text
+	if !iruleEvents[event] {
+		findings = append(findings, iruleFinding{line: words[1].line, severity: diag.Warning, message: fmt.Sprintf("unknown event %q", event)})
+	}
+	options := words[2 : len(words)-1]
+	for i := 0; i < len(options); i += 2 {
+		option := options[i].text
+		if (option != "priority" && option != "timing") || i+1 >= len(options) {
+			findings = append(findings, iruleFinding{line: options[i].line, severity: diag.Error, message: fmt.Sprintf("unexpected argument %q for event %s, expected \"priority <n>\" or \"timing on|off\"", option, event)})
+			break
+		}
+	}
+	body := words[len(words)-1]
+	if !body.braced {
+		return append(findings, iruleFinding{line: body.line, severity: diag.Error, message: fmt.Sprintf("body of event %s must be enclosed in braces", event)})
+	}
+	return append(findings, walkIRuleScript(body, event, visit)...)
+}
+
+func walkIRuleScript(script tclWord, event string, visit iruleVisitor) []iruleFinding {
+	p := newTclParser(script.text, script.line)
+	commands := p.parse()
+	findings := p.findings
+	for _, command := range commands {
+		findings = append(findings, walkIRuleCommand(command, event, visit)...)
+	}
+	return findings
+}
+
+func walkIRuleSubstitutions(word tclWord, event string, visit iruleVisitor) []iruleFinding {
+	var findings []iruleFinding
+	for _, sub := range tclSubstitutions(word.text, word.line) {
+		findings = append(findings, walkIRuleScript(sub, event, visit)...)
+	}
+	return findings
+}
+
+func walkIRuleCommand(command tclCommand, event string, visit iruleVisitor) []iruleFinding {
+	findings := visit(command, event)
+	name := command.words[0].text
+	words := command.words
+	scripts := map[int]bool{}
+	expressions := map[int]bool{}
+	switch name {
+	case "if":
+		for i := 1; i < len(words); {
+			expressions[i] = true
+			i++
+			if i < len(words) && words[i].text == "then" {
+				i++
+			}
+			scripts[i] = true
+			i++
+			if i < len(words) && words[i].text == "else" {
+				scripts[i+1] = true
+				break
+			}
+			if i < len(words) && words[i].text == "elseif" {
+				i++
+				continue
+			}
+			break
+		}
+	case "while":
+		expressions[1] = true
+		scripts[2] = true
+	case "for":
+		scripts[1] = true
+		expressions[2] = true
+		scripts[3] = true
+		scripts[4] = true
+	case "foreach":
+		scripts[len(words)-1] = true
+	case "catch":
+		scripts[1] = true
+	case "expr":
+		for i := 1; i < len(words); i++ {
+			expressions[i] = true
+		}
+	case "after":
+		if len(words) > 2 && words[len(words)-1].braced {
+			scripts[len(words)-1] = true
+		}
+	case "switch":
+		findings = append(findings, walkIRuleSwitch(command, event, visit)...)
+	}
+	for i := 1; i < len(words); i++ {
+		word := words[i]
+		switch {
+		case scripts[i] && word.braced:
+			findings = append(findings, walkIRuleScript(word, event, visit)...)
+		case expressions[i] || !word.braced:
+			findings = append(findings, walkIRuleSubstitutions(word, event, visit)...)
+		}
+	}
+	return findings
+}
+
+func walkIRuleSwitch(command tclCommand, event string, visit iruleVisitor) []iruleFinding {
+	var findings []iruleFinding
+	words := command.words
+	i := 1
+	for i < len(words) && strings.HasPrefix(words[i].text, "-") && !words[i].braced {
+		i++
+		if words[i-1].text == "--" {
+			break
+		}
+	}
+	// skip the string being matched
+	i++
+	if i >= len(words) {
+		return findings
+	}
+	cases := words[i:]
+	if len(cases) == 1 && cases[0].braced {
+		cases = newTclParser(cases[0].text, cases[0].line).parseList()
+	}
+	for j := 1; j < len(cases); j += 2 {
+		if cases[j].text != "-" && cases[j].braced {
+			findings = append(findings, walkIRuleScript(cases[j], event, visit)...)
+		}
+	}
+	return findings
+}
+
+func lintIRuleCommand(command tclCommand, event string) []iruleFinding {
+	first := command.words[0]
+	if first.braced || strings.ContainsAny(first.text, "$[") {
+		return nil
+	}
+	return lintIRuleCommandName(first.text, first.line, event)
+}
+
+func lintIRuleCommandName(name string, line int, event string) []iruleFinding {
+	var findings []iruleFinding
+	if replacement, ok := iruleDeprecatedCommands[name]; ok {
+		message := fmt.Sprintf("command %q is deprecated", name)
+		if replacement != "" {
+			message = fmt.Sprintf("%s, use %s instead", message, replacement)
+		}
+		return append(findings, iruleFinding{line: line, severity: diag.Warning, message: message})
+	}
+	namespace := ""
+	if idx := strings.Index(name, "::"); idx >= 0 {
+		namespace = name[:idx]
+		if namespace == "" || namespace == "static" {
+			return findings
+		}
+		if !iruleNamespaces[namespace] {
+			return append(findings, iruleFinding{line: line, severity: diag.Warning, message: fmt.Sprintf("unknown command %q", name)})
+		}
+		if commands, ok := iruleNamespaceCommands[namespace]; ok && !commands[name[idx+2:]] {
+			return append(findings, iruleFinding{line: line, severity: diag.Warning, message: fmt.Sprintf("unknown command %q", name), advisory: true})
+		}
+	} else if name == "when" {
+		return append(findings, iruleFinding{line: line, severity: diag.Error, message: "when cannot be nested inside another event or proc"})
+	} else if !iruleCommands[name] {
+		return append(findings, iruleFinding{line: line, severity: diag.Warning, message: fmt.Sprintf("unknown command %q", name)})
+	}
+
+	switch {
+	case event == "RULE_INIT" && (iruleConnectionNamespaces[namespace] || iruleConnectionCommands[name]):
+		findings = append(findings, iruleFinding{line: line, severity: diag.Warning, message: fmt.Sprintf("command %q is not valid in event RULE_INIT, which runs without a connection", name)})
+	case iruleHTTPPreRequestEvents[event] && (namespace == "HTTP" || namespace == "HTTP2") && !iruleHTTPPreRequestCommands[name]:
+		findings = append(findings, iruleFinding{line: line, severity: diag.Warning, message: fmt.Sprintf("command %q is not valid in event %s, no HTTP request has been received yet", name, event)})
+	}
+	return findings
+}
+
+// validateIRuleDiag reports the iRule lint findings as attribute diagnostics at plan time.
+func validateIRuleDiag(value interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	body, ok := value.(string)
+	if !ok {
+		return diag.Errorf("expected type of irule to be string")
+	}
+	for _, finding := range lintIRule(body) {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      finding.severity,
+			Summary:       fmt.Sprintf("iRule %s", finding),
+			AttributePath: path,
+		})
+	}
+	return diags
+}
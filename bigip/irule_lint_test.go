@@ -0,0 +1,167 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLintIRuleValid(t *testing.T) {
+	rules := map[string]string{
+		"simple": `
+when CLIENT_ACCEPTED {
+     log local0. "test"
+}`,
+		"websocket": `
+when HTTP_REQUEST {
+
+  if { [string tolower [HTTP::header value Upgrade]] equals "websocket" } {
+    HTTP::disable
+#    ASM::disable
+    log local0. "[IP::client_addr] - Connection upgraded to websocket protocol. Disabling ASM-checks and HTTP protocol. Traffic is treated as L4 TCP stream."
+  } else {
+    HTTP::enable
+#    ASM::enable
+    log local0. "[IP::client_addr] - Regular HTTP request. ASM-checks and HTTP protocol enabled. Traffic is deep-inspected at L7."
+  }
+}`,
+		"switch and proc": `
+proc pick_pool { uri } {
+  return [string range $uri 0 3]
+}
+when RULE_INIT priority 100 {
+  set static::debug 0
+}
+when HTTP_REQUEST timing on {
+  switch -glob -- [string tolower [HTTP::uri]] {
+    "/api*" -
+    "/v2*" {
+      pool api_pool
+    }
+    default {
+      if { $static::debug } { log local0. "default [call pick_pool [HTTP::uri]]" }
+      pool web_pool
+    }
+  }
+  foreach header [HTTP::header names] {
+    if { $header starts_with "X-" } { HTTP::header remove $header }
+  }
+}`,
+	}
+	for name, rule := range rules {
+		assert.Empty(t, lintIRule(rule), "%s should not report findings", name)
+	}
+}
+
+func TestLintIRuleFindings(t *testing.T) {
+	data := []struct {
+		name     string
+		rule     string
+		line     int
+		severity diag.Severity
+		message  string
+	}{
+		{
+			name:     "Missing close brace",
+			rule:     "when HTTP_REQUEST {\n  if { 1 } {\n    pool p\n}\n",
+			line:     1,
+			severity: diag.Error,
+			message:  "missing close-brace",
+		},
+		{
+			name:     "Unmatched close brace",
+			rule:     "when HTTP_REQUEST {\n  pool p\n}\n}\n",
+			line:     4,
+			severity: diag.Error,
+			message:  "unmatched close-brace",
+		},
+		{
+			name:     "Missing close bracket",
+			rule:     "when HTTP_REQUEST {\n  set uri [HTTP::uri\n}\n",
+			line:     2,
+			severity: diag.Error,
+			message:  "missing close-bracket",
+		},
+		{
+			name:     "Command outside of event",
+			rule:     "set x 1\nwhen HTTP_REQUEST {\n  pool p\n}\n",
+			line:     1,
+			severity: diag.Error,
+			message:  `unexpected command "set"`,
+		},
+		{
+			name:     "Unknown event",
+			rule:     "when HTTP_REQEST {\n  pool p\n}\n",
+			line:     1,
+			severity: diag.Warning,
+			message:  `unknown event "HTTP_REQEST"`,
+		},
+		{
+			name:     "Unknown command",
+			rule:     "when HTTP_REQUEST {\n  HTTP::redirekt \"/\"\n}\n",
+			line:     2,
+			severity: diag.Warning,
+			message:  `unknown command "HTTP::redirekt"`,
+		},
+		{
+			name:     "Deprecated command",
+			rule:     "when HTTP_REQUEST {\n\n  if { [matchclass [IP::client_addr] equals blocked] } {\n    drop\n  }\n}\n",
+			line:     3,
+			severity: diag.Warning,
+			message:  `command "matchclass" is deprecated, use class match instead`,
+		},
+		{
+			name:     "Command not valid in event",
+			rule:     "when CLIENT_ACCEPTED {\n  HTTP::redirect \"/\"\n}\n",
+			line:     2,
+			severity: diag.Warning,
+			message:  `not valid in event CLIENT_ACCEPTED`,
+		},
+		{
+			name:     "Connection command in RULE_INIT",
+			rule:     "when RULE_INIT {\n  pool p\n}\n",
+			line:     2,
+			severity: diag.Warning,
+			message:  `not valid in event RULE_INIT`,
+		},
+	}
+	for _, d := range data {
+		t.Run(d.name, func(t *testing.T) {
+			findings := lintIRule(d.rule)
+			if assert.Len(t, findings, 1) {
+				assert.Equal(t, d.line, findings[0].line)
+				assert.Equal(t, d.severity, findings[0].severity)
+				assert.Contains(t, findings[0].message, d.message)
+			}
+		})
+	}
+}
+
+func TestValidateIRuleDiag(t *testing.T) {
+	path := cty.GetAttrPath("irule")
+	diags := validateIRuleDiag("when HTTP_REQEST {\n  http_uri\n}\n", path)
+	if assert.Len(t, diags, 2) {
+		assert.Equal(t, "iRule line 1: unknown event \"HTTP_REQEST\"", diags[0].Summary)
+		assert.Equal(t, "iRule line 2: command \"http_uri\" is deprecated, use HTTP::uri instead", diags[1].Summary)
+		assert.Equal(t, path, diags[0].AttributePath)
+		assert.False(t, diags.HasError())
+	}
+}
+
+func TestIRuleStrictWarnings(t *testing.T) {
+	assert.Empty(t, iruleStrictWarnings("when CLIENT_ACCEPTED {\n  TCP::respond \"HELLO\\r\\n\"\n}\n"))
+	// the commands of a namespace are not all known, so an unknown one does not fail strict mode
+	rule := "when HTTP_REQUEST {\n  HTTP::redirekt \"/\"\n}\n"
+	assert.NotEmpty(t, lintIRule(rule))
+	assert.Empty(t, iruleStrictWarnings(rule))
+	assert.Equal(t, []string{`line 2: unknown command "FOO::bar"`}, iruleStrictWarnings("when HTTP_REQUEST {\n  FOO::bar\n}\n"))
+	assert.Equal(t, []string{`line 2: command "matchclass" is deprecated, use class match instead`},
+		iruleStrictWarnings("when HTTP_REQUEST {\n  if { [matchclass [IP::client_addr] equals blocked] } { drop }\n}\n"))
+}
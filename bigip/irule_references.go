@@ -0,0 +1,436 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	iruleReferenceDataGroup = "datagroup"
+	iruleReferencePool      = "pool"
+	iruleReferenceIFile     = "ifile"
+	// iruleReferenceSysIFile is the system iFile an LTM iFile uses, which iRules do not reference directly
+	iruleReferenceSysIFile = "sys-ifile"
+)
+
+// iruleReferenceURLs are the collections an iRule reference of each type is looked up in.
+var iruleReferenceURLs = map[string][]string{
+	iruleReferenceDataGroup: {"ltm/data-group/internal/", "ltm/data-group/external/"},
+	iruleReferencePool:      {"ltm/pool/"},
+	iruleReferenceIFile:     {"ltm/ifile/"},
+}
+
+// iruleClassArgument is the position of the data group name in the arguments of each class
+// subcommand, after the options.
+var iruleClassArgument = map[string]int{
+	"match":       2,
+	"search":      0,
+	"lookup":      1,
+	"element":     1,
+	"exists":      0,
+	"size":        0,
+	"type":        0,
+	"names":       0,
+	"get":         0,
+	"startsearch": 0,
+}
+
+// iruleReference is a data group, pool or LTM iFile referenced by name from an iRule.
+type iruleReference struct {
+	kind string
+	name string
+	line int
+}
+
+func (r iruleReference) String() string {
+	return fmt.Sprintf("%s %s (line %d)", r.kind, r.name, r.line)
+}
+
+func iruleReferenceSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the referenced object, one of datagroup, pool or ifile",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the referenced object as written in the iRule",
+			},
+		},
+	}
+}
+
+// iruleReferences returns the data groups, pools and LTM iFiles an iRule references with a literal
+// name, sorted by type and name. References built from variables or command substitutions are skipped.
+func iruleReferences(body string) []iruleReference {
+	var references []iruleReference
+	walkIRule(body, func(command tclCommand, event string) []iruleFinding {
+		if reference, ok := iruleCommandReference(command); ok {
+			references = append(references, reference)
+		}
+		return nil
+	})
+	sort.SliceStable(references, func(i, j int) bool {
+		if references[i].kind != references[j].kind {
+			return references[i].kind < references[j].kind
+		}
+		return references[i].name < references[j].name
+	})
+	var unique []iruleReference
+	for i, reference := range references {
+		if i > 0 && reference.kind == references[i-1].kind && reference.name == references[i-1].name {
+			continue
+		}
+		unique = append(unique, reference)
+	}
+	return unique
+}
+
+func iruleCommandReference(command tclCommand) (iruleReference, bool) {
+	words := command.words
+	if words[0].braced || len(words) < 2 {
+		return iruleReference{}, false
+	}
+	args := words[1:]
+	var kind string
+	var word tclWord
+	switch words[0].text {
+	case "class":
+		position, ok := iruleClassArgument[args[0].text]
+		args = skipTclOptions(args[1:])
+		if !ok || position >= len(args) {
+			return iruleReference{}, false
+		}
+		kind, word = iruleReferenceDataGroup, args[position]
+	case "matchclass":
+		if len(args) < 3 {
+			return iruleReference{}, false
+		}
+		kind, word = iruleReferenceDataGroup, args[2]
+	case "findclass":
+		if len(args) < 2 {
+			return iruleReference{}, false
+		}
+		kind, word = iruleReferenceDataGroup, args[1]
+	case "ifile":
+		if args[0].text == "listall" || len(args) < 2 {
+			return iruleReference{}, false
+		}
+		kind, word = iruleReferenceIFile, args[1]
+	case "pool":
+		kind, word = iruleReferencePool, args[0]
+	default:
+		return iruleReference{}, false
+	}
+	if word.text == "" || strings.ContainsAny(word.text, "$[ ") || strings.HasPrefix(word.text, "-") {
+		return iruleReference{}, false
+	}
+	return iruleReference{kind: kind, name: word.text, line: word.line}, true
+}
+
+func skipTclOptions(words []tclWord) []tclWord {
+	for i, word := range words {
+		if word.text == "--" {
+			return words[i+1:]
+		}
+		if word.braced || !strings.HasPrefix(word.text, "-") {
+			return words[i:]
+		}
+	}
+	return nil
+}
+
+func flattenIRuleReferences(references []iruleReference) []interface{} {
+	result := make([]interface{}, 0, len(references))
+	for _, reference := range references {
+		result = append(result, map[string]interface{}{
+			"type": reference.kind,
+			"name": reference.name,
+		})
+	}
+	return result
+}
+
+// iruleReferenceCandidates returns the full paths a name used in an iRule of partition can resolve to.
+// Names without a partition are looked up in the partition of the iRule first, then in Common.
+func iruleReferenceCandidates(partition, name string) []string {
+	if strings.HasPrefix(name, "/") {
+		return []string{name}
+	}
+	candidates := []string{fmt.Sprintf("/%s/%s", partition, name)}
+	if partition != "Common" {
+		candidates = append(candidates, "/Common/"+name)
+	}
+	return candidates
+}
+
+func iruleReferenceExists(client *bigip.BigIP, kind, fullPath string) (bool, error) {
+	for _, url := range iruleReferenceURLs[kind] {
+		_, err := client.APICall(&bigip.APIRequest{
+			Method:      "get",
+			URL:         url + strings.ReplaceAll(fullPath, "/", "~"),
+			ContentType: "application/json",
+		})
+		if err == nil {
+			return true, nil
+		}
+		if !isIRuleReferenceNotFound(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// iruleReferencesTo returns the iRules on the BIG-IP that reference the object of kind at fullPath.
+func iruleReferencesTo(client *bigip.BigIP, kind, fullPath string) ([]string, error) {
+	rules, err := client.IRules()
+	if err != nil {
+		return nil, err
+	}
+	var referencing []string
+	for _, rule := range rules.IRules {
+		if iruleReferencesPath(rule, kind, fullPath) {
+			referencing = append(referencing, rule.FullPath)
+		}
+	}
+	sort.Strings(referencing)
+	return referencing, nil
+}
+
+func iruleReferencesPath(rule bigip.IRule, kind, fullPath string) bool {
+	for _, reference := range iruleReferences(rule.Rule) {
+		if reference.kind != kind {
+			continue
+		}
+		for _, candidate := range iruleReferenceCandidates(rule.Partition, reference.name) {
+			if candidate == fullPath {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// iruleReferenceDeleteTimeout is how long the deletion of an object referenced by iRules waits for the apply to
+// update or delete them.
+var (
+	iruleReferenceDeleteTimeout  = 30 * time.Second
+	iruleReferenceDeleteInterval = 2 * time.Second
+)
+
+// checkIRuleReferencesTo fails the deletion of an object still referenced by an iRule, since removing it breaks
+// the traffic handled by that iRule. Terraform does not plan the destruction of resources removed from the
+// configuration with the provider, so they are only checked here. iRules the apply updates or deletes at the
+// same time are waited for.
+func checkIRuleReferencesTo(ctx context.Context, client *bigip.BigIP, kind, fullPath string) error {
+	forgetPlannedIRuleObjects(client)
+	deadline := time.Now().Add(iruleReferenceDeleteTimeout)
+	for {
+		rules, err := iruleReferencesTo(client, kind, fullPath)
+		if err != nil {
+			log.Printf("[WARN] Unable to check iRules referencing %s %s: %v", kind, fullPath, err)
+			return nil
+		}
+		if len(rules) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%s %s is referenced by iRule(s) %s, remove the references before deleting it", kind, fullPath, strings.Join(rules, ", "))
+		}
+		log.Printf("[DEBUG] %s %s is referenced by iRule(s) %s, waiting for them to change", kind, fullPath, strings.Join(rules, ", "))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(iruleReferenceDeleteInterval):
+		}
+	}
+}
+
+func isIRuleReferenceNotFound(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "01020036") ||
+		strings.Contains(strings.ToLower(msg), "not found") ||
+		strings.Contains(msg, "404")
+}
+
+const (
+	// iruleObjectCreated is an object the plan creates, or changes so that it uses other objects
+	iruleObjectCreated   = "created"
+	iruleObjectDestroyed = "destroyed"
+)
+
+// plannedIRuleObjects holds the data groups, pools and iFiles the plan creates or destroys, by type and full
+// path, recorded by their CustomizeDiff. Terraform plans an iRule after the objects it references by attribute
+// or with depends_on, so that its references to them are resolved against the plan rather than the BIG-IP. The
+// objects of a client are forgotten once the apply starts changing iRules or deleting the objects.
+var plannedIRuleObjects = struct {
+	sync.Mutex
+	actions map[*bigip.BigIP]map[string]string
+}{actions: make(map[*bigip.BigIP]map[string]string)}
+
+func planIRuleObject(client *bigip.BigIP, kind, fullPath, action string) {
+	plannedIRuleObjects.Lock()
+	defer plannedIRuleObjects.Unlock()
+	if plannedIRuleObjects.actions[client] == nil {
+		plannedIRuleObjects.actions[client] = make(map[string]string)
+	}
+	plannedIRuleObjects.actions[client][kind+" "+fullPath] = action
+}
+
+func forgetPlannedIRuleObjects(client *bigip.BigIP) {
+	plannedIRuleObjects.Lock()
+	defer plannedIRuleObjects.Unlock()
+	delete(plannedIRuleObjects.actions, client)
+}
+
+// plannedIRuleObject returns whether the plan creates or destroys the object of kind at fullPath.
+func plannedIRuleObject(client *bigip.BigIP, kind, fullPath string) string {
+	plannedIRuleObjects.Lock()
+	defer plannedIRuleObjects.Unlock()
+	return plannedIRuleObjects.actions[client][kind+" "+fullPath]
+}
+
+func plansIRuleObjectDestruction(client *bigip.BigIP) bool {
+	plannedIRuleObjects.Lock()
+	defer plannedIRuleObjects.Unlock()
+	for _, action := range plannedIRuleObjects.actions[client] {
+		if action == iruleObjectDestroyed {
+			return true
+		}
+	}
+	return false
+}
+
+// customizeDiffIRuleObject records the object of kind the resource creates, or destroys when its full path
+// changes. fullPath returns the planned full path of the object, empty while it is unknown. Changes of uses,
+// the attributes referencing other objects, are recorded as creations.
+func customizeDiffIRuleObject(kind string, fullPath func(d *schema.ResourceDiff) string, uses ...string) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		client, ok := meta.(*bigip.BigIP)
+		path := fullPath(d)
+		if !ok || path == "" {
+			return nil
+		}
+		if d.Id() != path || (len(uses) > 0 && d.HasChanges(uses...)) {
+			planIRuleObject(client, kind, path, iruleObjectCreated)
+		}
+		if d.Id() != "" && d.Id() != path {
+			planIRuleObject(client, kind, d.Id(), iruleObjectDestroyed)
+		}
+		return nil
+	}
+}
+
+// plannedName returns the planned full path of an object named by its full path, empty while it is unknown.
+func plannedName(d *schema.ResourceDiff) string {
+	if !d.NewValueKnown("name") {
+		return ""
+	}
+	return d.Get("name").(string)
+}
+
+// plannedIFileFullPath returns the planned full path of a system or LTM iFile, empty while it is unknown.
+func plannedIFileFullPath(d *schema.ResourceDiff) string {
+	for _, key := range []string{"name", "partition", "sub_path"} {
+		if !d.NewValueKnown(key) {
+			return ""
+		}
+	}
+	return buildIFileFullPath(d.Get("partition").(string), d.Get("sub_path").(string), d.Get("name").(string))
+}
+
+// iruleReferenceProblem is a reference of an iRule that will not resolve once the plan is applied.
+type iruleReferenceProblem struct {
+	reference iruleReference
+	reason    string
+}
+
+func (p iruleReferenceProblem) String() string {
+	if p.reason == "" {
+		return p.reference.String()
+	}
+	return fmt.Sprintf("%s %s", p.reference, p.reason)
+}
+
+// checkIRuleReferences returns the references of an iRule in partition that do not exist on the BIG-IP. With
+// planned, objects the plan creates are taken as existing, and objects it destroys as missing.
+func checkIRuleReferences(client *bigip.BigIP, partition string, references []iruleReference, planned bool) ([]iruleReferenceProblem, error) {
+	var problems []iruleReferenceProblem
+	for _, reference := range references {
+		found := false
+		reason := ""
+		for _, candidate := range iruleReferenceCandidates(partition, reference.name) {
+			if planned {
+				action := plannedIRuleObject(client, reference.kind, candidate)
+				if action == iruleObjectCreated {
+					found = true
+					break
+				}
+				if action == iruleObjectDestroyed {
+					reason = "(destroyed by this plan)"
+					continue
+				}
+			}
+			exists, err := iruleReferenceExists(client, reference.kind, candidate)
+			if err != nil {
+				return nil, fmt.Errorf("error looking up %s %s: %v", reference.kind, candidate, err)
+			}
+			if !exists {
+				continue
+			}
+			found = true
+			if planned && reference.kind == iruleReferenceIFile && plansIRuleObjectDestruction(client) {
+				reason, err = iruleIFileDestroyed(client, candidate)
+				if err != nil {
+					return nil, err
+				}
+				found = reason == ""
+			}
+			break
+		}
+		if !found {
+			problems = append(problems, iruleReferenceProblem{reference: reference, reason: reason})
+		}
+	}
+	return problems, nil
+}
+
+// iruleIFileDestroyed returns why the LTM iFile at fullPath will not resolve when the plan destroys the system
+// iFile it uses.
+func iruleIFileDestroyed(client *bigip.BigIP, fullPath string) (string, error) {
+	ifile, err := client.GetLtmIFile(fullPath)
+	if err != nil {
+		return "", fmt.Errorf("error looking up ifile %s: %v", fullPath, err)
+	}
+	if ifile == nil {
+		return "", nil
+	}
+	if plannedIRuleObject(client, iruleReferenceSysIFile, ifile.FileName) == iruleObjectDestroyed {
+		return fmt.Sprintf("(system iFile %s destroyed by this plan)", ifile.FileName), nil
+	}
+	return "", nil
+}
+
+func iruleReferencesString(problems []iruleReferenceProblem) string {
+	var names []string
+	for _, problem := range problems {
+		names = append(names, problem.String())
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIRuleReferences(t *testing.T) {
+	rule := `
+when HTTP_REQUEST {
+  if { [class match [IP::client_addr] equals blocked_clients] } {
+    drop
+  } elseif { [class match -value -- [HTTP::uri] starts_with /Common/uri_map] ne "" } {
+    pool [class lookup [HTTP::host] host_pools]
+  } elseif { [matchclass [HTTP::host] equals legacy_hosts] } {
+    pool /Common/legacy_pool member 10.0.0.1 80
+  } else {
+    pool web_pool
+  }
+  set dg "dynamic"
+  if { [class exists $dg] } {
+    pool $dg
+  }
+  HTTP::respond 503 content [ifile get maintenance_page]
+  log local0. "[ifile listall]"
+  pool web_pool
+}`
+	references := iruleReferences(rule)
+	assert.Equal(t, []iruleReference{
+		{kind: iruleReferenceDataGroup, name: "/Common/uri_map", line: 5},
+		{kind: iruleReferenceDataGroup, name: "blocked_clients", line: 3},
+		{kind: iruleReferenceDataGroup, name: "host_pools", line: 6},
+		{kind: iruleReferenceDataGroup, name: "legacy_hosts", line: 7},
+		{kind: iruleReferenceIFile, name: "maintenance_page", line: 16},
+		{kind: iruleReferencePool, name: "/Common/legacy_pool", line: 8},
+		{kind: iruleReferencePool, name: "web_pool", line: 10},
+	}, references)
+}
+
+func TestIRuleReferenceCandidates(t *testing.T) {
+	assert.Equal(t, []string{"/Common/dg"}, iruleReferenceCandidates("Common", "dg"))
+	assert.Equal(t, []string{"/Tenant/dg", "/Common/dg"}, iruleReferenceCandidates("Tenant", "dg"))
+	assert.Equal(t, []string{"/Other/dg"}, iruleReferenceCandidates("Tenant", "/Other/dg"))
+}
+
+func TestCheckIRuleReferences(t *testing.T) {
+	setup()
+	defer teardown()
+	for _, path := range []string{"ltm/data-group/internal/~Tenant~paths", "ltm/pool/~Common~p", "ltm/ifile/~Tenant~page"} {
+		mux.HandleFunc("/mgmt/tm/"+path, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = fmt.Fprint(w, `{"name": "exists", "fileName": "/Common/page.html"}`)
+		})
+	}
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+	rule := "when HTTP_REQUEST {\n  if { [class match [HTTP::uri] starts_with paths] } { pool p }\n  if { [class match [HTTP::uri] equals new_paths] } { pool q }\n  HTTP::respond 200 content [ifile get page]\n}"
+	references := iruleReferences(rule)
+
+	problems, err := checkIRuleReferences(client, "Tenant", references, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "datagroup new_paths (line 3), pool q (line 3)", iruleReferencesString(problems))
+
+	// new_paths and q are created by the plan, paths renamed, and the system iFile of page destroyed
+	planIRuleObject(client, iruleReferenceDataGroup, "/Tenant/new_paths", iruleObjectCreated)
+	planIRuleObject(client, iruleReferencePool, "/Common/q", iruleObjectCreated)
+	planIRuleObject(client, iruleReferenceDataGroup, "/Tenant/paths", iruleObjectDestroyed)
+	planIRuleObject(client, iruleReferenceSysIFile, "/Common/page.html", iruleObjectDestroyed)
+	problems, err = checkIRuleReferences(client, "Tenant", references, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "datagroup paths (line 2) (destroyed by this plan), ifile page (line 4) (system iFile /Common/page.html destroyed by this plan)",
+		iruleReferencesString(problems))
+
+	// after the apply only the BIG-IP is looked up
+	problems, err = checkIRuleReferences(client, "Tenant", references, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "datagroup new_paths (line 3), pool q (line 3)", iruleReferencesString(problems))
+}
+
+func TestDataGroupDeleteReferencedByIRule(t *testing.T) {
+	iruleReferenceDeleteTimeout, iruleReferenceDeleteInterval = 20*time.Millisecond, time.Millisecond
+	defer func() {
+		iruleReferenceDeleteTimeout, iruleReferenceDeleteInterval = 30*time.Second, 2*time.Second
+	}()
+	rule := `{"name": "block", "partition": "Common", "fullPath": "/Common/block", "apiAnonymous": "when CLIENT_ACCEPTED {\n  if { [class match [IP::client_addr] equals blocked_clients] } { reject }\n}"}`
+	// the data group was removed from the configuration, so Terraform deletes it without planning it
+	run := func(t *testing.T, referencedLists int) (diag.Diagnostics, bool) {
+		setup()
+		defer teardown()
+		lists := 0
+		mux.HandleFunc("/mgmt/tm/ltm/rule", func(w http.ResponseWriter, r *http.Request) {
+			lists++
+			if lists <= referencedLists {
+				_, _ = fmt.Fprintf(w, `{"items": [%s]}`, rule)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"items": []}`)
+		})
+		deleted := false
+		mux.HandleFunc("/mgmt/tm/ltm/data-group/internal/~Common~blocked_clients", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "DELETE", r.Method)
+			deleted = true
+		})
+		client := bigip.NewSession(&bigip.Config{
+			Address:       server.URL,
+			ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+		})
+		d := schema.TestResourceDataRaw(t, resourceBigipLtmDataGroup().Schema, map[string]interface{}{
+			"name":     "/Common/blocked_clients",
+			"type":     "ip",
+			"internal": true,
+		})
+		d.SetId("/Common/blocked_clients")
+		return resourceBigipLtmDataGroupDelete(context.Background(), d, client), deleted
+	}
+
+	t.Run("Still referenced", func(t *testing.T) {
+		diags, deleted := run(t, 1000)
+		assert.True(t, diags.HasError())
+		assert.Contains(t, diags[0].Summary, "datagroup /Common/blocked_clients is referenced by iRule(s) /Common/block")
+		assert.False(t, deleted)
+	})
+	t.Run("iRule changed by the same apply", func(t *testing.T) {
+		diags, deleted := run(t, 2)
+		assert.False(t, diags.HasError())
+		assert.True(t, deleted)
+	})
+	t.Run("Not referenced", func(t *testing.T) {
+		diags, deleted := run(t, 0)
+		assert.False(t, diags.HasError())
+		assert.True(t, deleted)
+	})
+}
@@ -104,26 +104,36 @@ func Provider() *schema.Provider {
 				Description: "Amount of times to retry AS3 API requests. Default: 10.",
 				DefaultFunc: schema.EnvDefaultFunc("API_RETRIES", 10),
 			},
+			"bigiq": bigiqProviderSchema(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"bigip_ltm_datagroup":                 dataSourceBigipLtmDataGroup(),
-			"bigip_ltm_monitor":                   dataSourceBigipLtmMonitor(),
-			"bigip_ltm_irule":                     dataSourceBigipLtmIrule(),
-			"bigip_ssl_certificate":               dataSourceBigipSslCertificate(),
-			"bigip_ltm_pool":                      dataSourceBigipLtmPool(),
-			"bigip_ltm_policy":                    dataSourceBigipLtmPolicy(),
-			"bigip_ltm_node":                      dataSourceBigipLtmNode(),
-			"bigip_vwan_config":                   dataSourceBigipVwanconfig(),
-			"bigip_waf_signatures":                dataSourceBigipWafSignatures(),
-			"bigip_waf_policy":                    dataSourceBigipWafPolicy(),
-			"bigip_waf_pb_suggestions":            dataSourceBigipWafPb(),
-			"bigip_waf_entity_url":                dataSourceBigipWafEntityUrl(),
-			"bigip_waf_entity_parameter":          dataSourceBigipWafEntityParameter(),
-			"bigip_fast_consul_service_discovery": dataSourceBigipFastConsulServiceDiscovery(),
-			"bigip_fast_aws_service_discovery":    dataSourceBigipFastAwsServiceDiscovery(),
-			"bigip_fast_azure_service_discovery":  dataSourceBigipFastAzureServiceDiscovery(),
-			"bigip_fast_gce_service_discovery":    dataSourceBigipFastGceServiceDiscovery(),
-			"bigip_as3_device_information":        dataSourceBigipAs3(),
+			"bigip_ltm_datagroup":                     dataSourceBigipLtmDataGroup(),
+			"bigip_ltm_monitor":                       dataSourceBigipLtmMonitor(),
+			"bigip_ltm_irule":                         dataSourceBigipLtmIrule(),
+			"bigip_ssl_certificate":                   dataSourceBigipSslCertificate(),
+			"bigip_ltm_pool":                          dataSourceBigipLtmPool(),
+			"bigip_ltm_policy":                        dataSourceBigipLtmPolicy(),
+			"bigip_ltm_node":                          dataSourceBigipLtmNode(),
+			"bigip_ltm_pool_ephemeral_members":        dataSourceBigipLtmPoolEphemeralMembers(),
+			"bigip_vwan_config":                       dataSourceBigipVwanconfig(),
+			"bigip_waf_signatures":                    dataSourceBigipWafSignatures(),
+			"bigip_waf_policy":                        dataSourceBigipWafPolicy(),
+			"bigip_waf_pb_suggestions":                dataSourceBigipWafPb(),
+			"bigip_waf_entity_url":                    dataSourceBigipWafEntityUrl(),
+			"bigip_waf_entity_parameter":              dataSourceBigipWafEntityParameter(),
+			"bigip_fast_consul_service_discovery":     dataSourceBigipFastConsulServiceDiscovery(),
+			"bigip_fast_aws_service_discovery":        dataSourceBigipFastAwsServiceDiscovery(),
+			"bigip_fast_azure_service_discovery":      dataSourceBigipFastAzureServiceDiscovery(),
+			"bigip_fast_gce_service_discovery":        dataSourceBigipFastGceServiceDiscovery(),
+			"bigip_fast_kubernetes_service_discovery": dataSourceBigipFastKubernetesServiceDiscovery(),
+			"bigip_fast_file_service_discovery":       dataSourceBigipFastFileServiceDiscovery(),
+			"bigip_as3_device_information":            dataSourceBigipAs3(),
+			"bigip_as3_declaration":                   dataSourceBigipAs3Declaration(),
+			"bigip_ts_info":                           dataSourceBigipTsInfo(),
+			"bigip_fast_template_info":                dataSourceBigipFastTemplateInfo(),
+			"bigip_fast_render":                       dataSourceBigipFastRender(),
+			"bigip_bigiq_managed_devices":             dataSourceBigiqManagedDevices(),
+			"bigip_device_info":                       dataSourceBigipDeviceInfo(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
 			"bigip_cm_device":                       resourceBigipCmDevice(),
@@ -138,6 +148,7 @@ func Provider() *schema.Provider {
 			"bigip_ltm_node":                        resourceBigipLtmNode(),
 			"bigip_ltm_pool":                        resourceBigipLtmPool(),
 			"bigip_ltm_pool_attachment":             resourceBigipLtmPoolAttachment(),
+			"bigip_ltm_pool_members":                resourceBigipLtmPoolMembers(),
 			"bigip_ltm_policy":                      resourceBigipLtmPolicy(),
 			"bigip_ltm_profile_fasthttp":            resourceBigipLtmProfileFasthttp(),
 			"bigip_ltm_profile_fastl4":              resourceBigipLtmProfileFastl4(),
@@ -164,12 +175,16 @@ func Provider() *schema.Provider {
 			"bigip_sys_ntp":                         resourceBigipSysNtp(),
 			"bigip_sys_ocsp":                        resourceBigipSysOcsp(),
 			"bigip_sys_provision":                   resourceBigipSysProvision(),
+			"bigip_sys_provisioning":                resourceBigipSysProvisioning(),
 			"bigip_sys_ifile":                       resourceBigipSysIfile(),
 			"bigip_sys_snmp":                        resourceBigipSysSnmp(),
 			"bigip_sys_snmp_traps":                  resourceBigipSysSnmpTraps(),
 			"bigip_sys_bigiplicense":                resourceBigipSysBigiplicense(),
 			"bigip_as3":                             resourceBigipAs3(),
+			"bigip_as3_application":                 resourceBigipAs3Application(),
 			"bigip_do":                              resourceBigipDo(),
+			"bigip_ts":                              resourceBigipTs(),
+			"bigip_atc_package":                     resourceBigipAtcPackage(),
 			"bigip_fast_template":                   resourceBigipFastTemplate(),
 			"bigip_fast_application":                resourceBigipFastApp(),
 			"bigip_fast_http_app":                   resourceBigipHttpFastApp(),
@@ -182,6 +197,8 @@ func Provider() *schema.Provider {
 			"bigip_command":                         resourceBigipCommand(),
 			"bigip_common_license_manage_bigiq":     resourceBigiqLicenseManage(),
 			"bigip_bigiq_as3":                       resourceBigiqAs3(),
+			"bigip_bigiq_managed_device":            resourceBigiqManagedDevice(),
+			"bigip_bigiq_config_deployment":         resourceBigiqConfigDeployment(),
 			"bigip_event_service_discovery":         resourceServiceDiscovery(),
 			"bigip_traffic_selector":                resourceBigipTrafficselector(),
 			"bigip_ipsec_policy":                    resourceBigipIpsecPolicy(),
@@ -246,6 +263,19 @@ func providerConfigure(d *schema.ResourceData, terraformVersion string) (interfa
 		cfg.UserAgent += fmt.Sprintf("/terraform-provider-bigip/%s", getVersion())
 		cfg.Teem = d.Get("teem_disable").(bool)
 		cfg.Transport.TLSClientConfig.InsecureSkipVerify = d.Get("validate_certs_disable").(bool)
+		setProviderConnection(cfg, providerConnection{
+			certVerifyDisable:  config.CertVerifyDisable,
+			trustedCertificate: config.TrustedCertificate,
+			loginReference:     d.Get("login_ref").(string),
+			configOptions:      configOptions,
+		})
+	}
+	if _, ok := d.GetOk("bigiq"); ok && cfg != nil {
+		session, err := newBigiqSession(d, configOptions)
+		if err != nil {
+			return cfg, diag.FromErr(err)
+		}
+		setBigiqSession(cfg, session)
 	}
 	return cfg, diag.FromErr(err)
 }
@@ -13,9 +13,7 @@ import (
 	"log"
 	"math/big"
 	"os"
-	"reflect"
 	"strings"
-	"sync"
 	"time"
 
 	bigip "github.com/efellowsbg/go-bigip"
@@ -25,11 +23,10 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // var x = 0
-var m sync.Mutex
-var createdTenants string
 
 func resourceBigipAs3() *schema.Resource {
 	return &schema.Resource{
@@ -37,6 +34,12 @@ func resourceBigipAs3() *schema.Resource {
 		ReadContext:   resourceBigipAs3Read,
 		UpdateContext: resourceBigipAs3Update,
 		DeleteContext: resourceBigipAs3Delete,
+		CustomizeDiff: resourceBigipAs3CustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 				// d.Id() here is the last argument passed to the `terraform import RESOURCE_TYPE.RESOURCE_NAME RESOURCE_ID` command
@@ -59,86 +62,9 @@ func resourceBigipAs3() *schema.Resource {
 					return jsonString
 				},
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-					oldResp := []byte(old)
-					newResp := []byte(new)
-					oldJsonref := make(map[string]interface{})
-					newJsonref := make(map[string]interface{})
-					_ = json.Unmarshal(oldResp, &oldJsonref)
-					_ = json.Unmarshal(newResp, &newJsonref)
-					delete(oldJsonref, "$schema")
-					delete(newJsonref, "$schema")
-					jsonEqualityBefore := reflect.DeepEqual(oldJsonref, newJsonref)
-					if jsonEqualityBefore {
-						return true
-					}
-					for key, value := range oldJsonref {
-						if rec, ok := value.(map[string]interface{}); ok && key == "declaration" {
-							for range rec {
-								delete(rec, "updateMode")
-								delete(rec, "schemaVersion")
-								delete(rec, "id")
-								delete(rec, "label")
-								delete(rec, "remark")
-								delete(rec, "Common")
-							}
-						}
-						if key == "persist" {
-							delete(oldJsonref, "persist")
-						}
-					}
-					for key, value := range newJsonref {
-						if rec, ok := value.(map[string]interface{}); ok && key == "declaration" {
-							for range rec {
-								delete(rec, "updateMode")
-								delete(rec, "schemaVersion")
-								delete(rec, "id")
-								delete(rec, "label")
-								delete(rec, "remark")
-								delete(rec, "Common")
-							}
-						}
-						if key == "persist" {
-							delete(newJsonref, "persist")
-						}
-					}
-					ignoreMetadata := d.Get("ignore_metadata").(bool)
-					jsonEqualityAfter := reflect.DeepEqual(oldJsonref, newJsonref)
-					if ignoreMetadata {
-						if jsonEqualityAfter {
-							return true
-						} else {
-							return false
-						}
-
-					} else {
-						if !jsonEqualityBefore {
-							return false
-						}
-					}
-					return true
-				},
-				ValidateFunc: func(v interface{}, k string) (ws []string, errors []error) {
-					if _, err := structure.NormalizeJsonString(v); err != nil {
-						errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
-					}
-					as3json := v.(string)
-					resp := []byte(as3json)
-					jsonRef := make(map[string]interface{})
-					_ = json.Unmarshal(resp, &jsonRef)
-					for key, value := range jsonRef {
-						if key == "class" && value != "AS3" {
-							errors = append(errors, fmt.Errorf("JSON must have AS3 class"))
-						}
-						if rec, ok := value.(map[string]interface{}); ok && key == "declaration" {
-							for k, v := range rec {
-								if k == "class" && v != "ADC" {
-									errors = append(errors, fmt.Errorf("JSON must have ADC class"))
-								}
-							}
-						}
-					}
-					return
+					return as3JsonDiffSuppress(old, new, d.Get("ignore_metadata").(bool))
 				},
+				ValidateDiagFunc: validateAs3Json,
 			},
 			"controls": {
 				Type: schema.TypeMap,
@@ -190,6 +116,18 @@ func resourceBigipAs3() *schema.Resource {
 				Computed:    true,
 				Description: "Will define Perapp mode enabled on BIG-IP or not",
 			},
+			"preview_changes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Post the declaration as an AS3 dry-run during the plan and show the resulting changes in planned_changes",
+			},
+			"planned_changes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "BIG-IP objects AS3 creates, modifies or deletes for the declaration, computed during the plan when preview_changes is set",
+				Elem:        as3PlannedChangeSchema(),
+			},
 			"delete_apps": {
 				Type:          schema.TypeList,
 				MaxItems:      1,    // Ensures only one delete_apps block is allowed
@@ -218,6 +156,67 @@ func resourceBigipAs3() *schema.Resource {
 	}
 }
 
+// resourceBigipAs3CustomizeDiff previews the changes of a modified declaration with an AS3 dry-run.
+func resourceBigipAs3CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("preview_changes").(bool) || !d.HasChange("as3_json") || meta == nil {
+		return nil
+	}
+	if !d.NewValueKnown("as3_json") {
+		return d.SetNewComputed("planned_changes")
+	}
+	as3Json := d.Get("as3_json").(string)
+	if as3Json == "" {
+		return nil
+	}
+	client := meta.(*bigip.BigIP)
+	tenantList, _, _ := client.GetTenantList(as3Json)
+	if tenantList == "" {
+		log.Printf("[INFO] Skipping AS3 dry-run of a per-application declaration")
+		return nil
+	}
+	if tenantFilter := d.Get("tenant_filter").(string); tenantFilter != "" {
+		tenantList = tenantFilter
+	}
+	defer lockAs3Tenants(client, tenantList)()
+	changes, err := as3DryRun(client, as3Json, tenantList)
+	if err != nil {
+		return fmt.Errorf("error previewing AS3 declaration changes for tenants (%s): %v", tenantList, err)
+	}
+	return d.SetNew("planned_changes", flattenAs3PlannedChanges(changes))
+}
+
+// validateAs3Json checks that as3_json is an AS3 request with an ADC declaration, and validates the
+// declaration against the embedded AS3 schema.
+func validateAs3Json(v interface{}, path cty.Path) diag.Diagnostics {
+	if diags := validation.ToDiagFunc(validateAs3JsonClass)(v, path); diags.HasError() {
+		return diags
+	}
+	return validateDeclaration(as3DeclarationSchemas, v.(string), path)
+}
+
+func validateAs3JsonClass(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := structure.NormalizeJsonString(v); err != nil {
+		errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+	}
+	as3json := v.(string)
+	resp := []byte(as3json)
+	jsonRef := make(map[string]interface{})
+	_ = json.Unmarshal(resp, &jsonRef)
+	for key, value := range jsonRef {
+		if key == "class" && value != "AS3" {
+			errors = append(errors, fmt.Errorf("JSON must have AS3 class"))
+		}
+		if rec, ok := value.(map[string]interface{}); ok && key == "declaration" {
+			for k, v := range rec {
+				if k == "class" && v != "ADC" {
+					errors = append(errors, fmt.Errorf("JSON must have ADC class"))
+				}
+			}
+		}
+	}
+	return
+}
+
 func validateControlsParam(val interface{}, path cty.Path) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -314,8 +313,6 @@ func controlsQueraString(d *schema.ResourceData) string {
 
 func resourceBigipAs3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	m.Lock()
-	defer m.Unlock()
 	as3Json := d.Get("as3_json").(string)
 	tenantFilter := d.Get("tenant_filter").(string)
 	deleteAppsBlocks := d.Get("delete_apps").([]interface{})
@@ -357,7 +354,9 @@ func resourceBigipAs3Create(ctx context.Context, d *schema.ResourceData, meta in
 		log.Printf("[DEBUG] tenant name :%+v", tenant)
 
 		applicationList := client.GetAppsList(as3Json)
-		err, taskID := client.PostPerAppBigIp(as3Json, tenant, controlsQuerParam)
+		unlock := lockAs3Tenants(client, tenant)
+		taskID, err := deployAs3Applications(ctx, client, as3Json, tenant, controlsQuerParam)
+		unlock()
 		log.Printf("[DEBUG] task Id from deployment :%+v", taskID)
 		if err != nil {
 			return diag.FromErr(fmt.Errorf("posting as3 config failed for tenants:(%s) with error: %v", tenantFilter, err))
@@ -387,7 +386,9 @@ func resourceBigipAs3Create(ctx context.Context, d *schema.ResourceData, meta in
 			return diag.FromErr(err)
 		}
 		log.Printf("[INFO] Creating as3 config in bigip:%s", strTrimSpace)
-		err, successfulTenants, taskID := client.PostAs3Bigip(strTrimSpace, tenantList, controlsQuerParam)
+		unlock := lockAs3Tenants(client, tenantList)
+		taskID, successfulTenants, err := deployAs3Tenants(ctx, client, strTrimSpace, tenantList, controlsQuerParam)
+		unlock()
 		log.Printf("[DEBUG] successfulTenants :%+v", successfulTenants)
 		if err != nil {
 			if successfulTenants == "" {
@@ -431,12 +432,25 @@ func resourceBigipAs3Create(ctx context.Context, d *schema.ResourceData, meta in
 	} else {
 		d.SetId("Common")
 	}
-	createdTenants = d.Get("tenant_list").(string)
-	return resourceBigipAs3Read(ctx, d, meta)
+	return readAppliedBigipAs3(ctx, d, meta)
 }
+
+// readAppliedBigipAs3 reads the applied declaration, keeping the planned_changes of the plan so that the state
+// matches it. They are emptied by the next refresh.
+func readAppliedBigipAs3(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	plannedChanges := d.Get("planned_changes")
+	diags := resourceBigipAs3Read(ctx, d, meta)
+	if d.Id() != "" {
+		_ = d.Set("planned_changes", plannedChanges)
+	}
+	return diags
+}
+
 func resourceBigipAs3Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
 	log.Printf("[INFO] Reading AS3 config")
+	// the changes previewed by the last plan are applied, so that later plans do not show them as pending
+	_ = d.Set("planned_changes", []interface{}{})
 	var name string
 	var tList string
 	as3Json := d.Get("as3_json").(string)
@@ -444,8 +458,8 @@ func resourceBigipAs3Read(ctx context.Context, d *schema.ResourceData, meta inte
 	log.Printf("[INFO] AS3 config:%+v", as3Json)
 	if d.Get("as3_json") != nil && !perappMode && d.Get("tenant_filter") == "" {
 		tList, _, _ = client.GetTenantList(as3Json)
-		if createdTenants != "" && createdTenants != tList {
-			tList = createdTenants
+		if deployedTenants := d.Get("tenant_list").(string); deployedTenants != "" && deployedTenants != tList {
+			tList = deployedTenants
 		}
 	}
 	if d.Id() != "" && tList != "" {
@@ -507,8 +521,6 @@ func resourceBigipAs3Read(ctx context.Context, d *schema.ResourceData, meta inte
 
 func resourceBigipAs3Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	m.Lock()
-	defer m.Unlock()
 	as3Json := d.Get("as3_json").(string)
 	deleteAppsBlocks := d.Get("delete_apps").([]interface{})
 
@@ -535,6 +547,7 @@ func resourceBigipAs3Update(ctx context.Context, d *schema.ResourceData, meta in
 	if d.Get("per_app_mode").(bool) {
 		if perApplication && len(tenantList) == 0 {
 			oldTenantList := d.Id()
+			defer lockAs3Tenants(client, oldTenantList)()
 			log.Printf("[INFO] oldApplicationList :%s", oldApplicationList)
 			curApplicationList := client.GetAppsList(as3Json)
 			log.Printf("[INFO] curApplicationList :%s", curApplicationList)
@@ -550,7 +563,7 @@ func resourceBigipAs3Update(ctx context.Context, d *schema.ResourceData, meta in
 			}
 
 			log.Printf("[INFO] Updating As3 Config for tenant:%s with Per-Application Mode:%v", oldTenantList, perApplication)
-			err, task_id := client.PostPerAppBigIp(as3Json, oldTenantList, controlsQuerParam)
+			task_id, err := deployAs3Applications(ctx, client, as3Json, oldTenantList, controlsQuerParam)
 			log.Printf("[DEBUG] task_id from PostPerAppBigIp:%+v", task_id)
 			if err != nil {
 				return diag.FromErr(fmt.Errorf("posting as3 config failed for tenant:(%s) with error: %v", oldTenantList, err))
@@ -572,6 +585,7 @@ func resourceBigipAs3Update(ctx context.Context, d *schema.ResourceData, meta in
 		log.Printf("[INFO] Updating As3 Config Traditionally for tenants:%s", tenantList)
 		oldTenantList := d.Get("tenant_list").(string)
 		tenantFilter := d.Get("tenant_filter").(string)
+		defer lockAs3Tenants(client, oldTenantList, tenantList)()
 		if tenantFilter == "" {
 			if tenantList != oldTenantList {
 				_ = d.Set("tenant_list", tenantList)
@@ -579,7 +593,7 @@ func resourceBigipAs3Update(ctx context.Context, d *schema.ResourceData, meta in
 				oldList := strings.Split(oldTenantList, ",")
 				deletedTenants := client.TenantDifference(oldList, newList)
 				if deletedTenants != "" {
-					err, _ := client.DeleteAs3Bigip(deletedTenants)
+					_, err := deleteAs3Tenants(ctx, client, deletedTenants)
 					if err != nil {
 						log.Printf("[ERROR] Unable to Delete removed tenants: %v :", err)
 						return diag.FromErr(err)
@@ -597,7 +611,7 @@ func resourceBigipAs3Update(ctx context.Context, d *schema.ResourceData, meta in
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		err, successfulTenants, taskID := client.PostAs3Bigip(strTrimSpace, tenantList, controlsQuerParam)
+		taskID, successfulTenants, err := deployAs3Tenants(ctx, client, strTrimSpace, tenantList, controlsQuerParam)
 		log.Printf("[DEBUG] successfulTenants :%+v", successfulTenants)
 		if err != nil {
 			if successfulTenants == "" {
@@ -611,18 +625,11 @@ func resourceBigipAs3Update(ctx context.Context, d *schema.ResourceData, meta in
 		_ = d.Set("task_id", taskID)
 		_ = d.Set("tenant_name", tenantList)
 	}
-	if d.Get("tenant_filter").(string) != "" {
-		createdTenants = d.Get("tenant_filter").(string)
-	} else {
-		createdTenants = d.Get("tenant_list").(string)
-	}
-	return resourceBigipAs3Read(ctx, d, meta)
+	return readAppliedBigipAs3(ctx, d, meta)
 }
 
 func resourceBigipAs3Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	m.Lock()
-	defer m.Unlock()
 	var name string
 	var tList string
 	as3Json := d.Get("as3_json").(string)
@@ -652,6 +659,7 @@ func resourceBigipAs3Delete(ctx context.Context, d *schema.ResourceData, meta in
 		name = d.Id()
 	}
 	log.Printf("[INFO] Deleting As3 config for tenants:%+v", name)
+	defer lockAs3Tenants(client, name)()
 	if d.Get("per_app_mode").(bool) {
 		applicationList := d.Get("application_list").(string)
 		log.Printf("[INFO] Deleting As3 config for Applications:%+v", applicationList)
@@ -664,14 +672,16 @@ func resourceBigipAs3Delete(ctx context.Context, d *schema.ResourceData, meta in
 			}
 		}
 	} else {
-		err, failedTenants := client.DeleteAs3Bigip(name)
+		failedTenants, err := deleteAs3Tenants(ctx, client, name)
 		if err != nil {
 			log.Printf("[ERROR] Unable to DeleteContext: %v :", err)
-			return diag.FromErr(err)
-		}
-		if failedTenants != "" {
-			_ = d.Set("tenant_list", name)
-			return resourceBigipAs3Read(ctx, d, meta)
+			if failedTenants == "" {
+				return diag.FromErr(err)
+			}
+			// the tenants that could not be deleted are kept in the state with their declaration
+			_ = d.Set("tenant_list", failedTenants)
+			diags := resourceBigipAs3Read(ctx, d, meta)
+			return append(diags, diag.FromErr(fmt.Errorf("error deleting AS3 tenants (%s): %v", failedTenants, err))...)
 		}
 	}
 	d.SetId("")
@@ -720,10 +730,12 @@ func handleDeleteApps(ctx context.Context, d *schema.ResourceData, client *bigip
 		}
 
 		log.Printf("[INFO] Deleting applications %v under tenant '%s'", appsToDelete, tenant)
+		unlock := lockAs3Tenants(client, tenant)
 
 		// Check if tenant exists
 		as3Resp, err := client.GetAs3(tenant, "", false)
 		if err != nil || len(as3Resp) == 0 {
+			unlock()
 			log.Printf("[WARN] Skipping deletion: Tenant '%s' not found or empty: %v", tenant, err)
 			continue // Do not fail – just skip this block
 		}
@@ -733,11 +745,13 @@ func handleDeleteApps(ctx context.Context, d *schema.ResourceData, client *bigip
 
 			err := client.DeletePerApplicationAs3Bigip(tenant, app)
 			if err != nil {
+				unlock()
 				log.Printf("[ERROR] Failed to delete application '%s' in tenant '%s': %v", app, tenant, err)
 				return diag.FromErr(fmt.Errorf("failed to delete app '%s': %v", app, err))
 			}
 			log.Printf("[INFO] Successfully deleted application '%s' in tenant '%s'", app, tenant)
 		}
+		unlock()
 	}
 	d.SetId(fmt.Sprintf("deleted-%s-%d", tenantName, time.Now().Unix()))
 
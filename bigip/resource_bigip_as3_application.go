@@ -0,0 +1,260 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+)
+
+// as3DeclarationProperties are the properties of a per-application declaration that are not applications.
+var as3DeclarationProperties = map[string]bool{
+	"schemaVersion": true,
+	"$schema":       true,
+	"id":            true,
+	"label":         true,
+	"remark":        true,
+	"controls":      true,
+}
+
+func resourceBigipAs3Application() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipAs3ApplicationCreate,
+		ReadContext:   resourceBigipAs3ApplicationRead,
+		UpdateContext: resourceBigipAs3ApplicationUpdate,
+		DeleteContext: resourceBigipAs3ApplicationDelete,
+		CustomizeDiff: resourceBigipAs3ApplicationCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBigipAs3ApplicationImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"tenant": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the tenant the application is deployed to. The tenant is created with its first application",
+			},
+			"application": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the application, which must be the only application of as3_json",
+			},
+			"as3_json": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Per-application AS3 declaration as a JSON string, with the schemaVersion and the application",
+				StateFunc: func(v interface{}) string {
+					jsonString, _ := structure.NormalizeJsonString(v)
+					return jsonString
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return as3JsonDiffSuppress(old, new, false)
+				},
+				ValidateDiagFunc: validateAs3Json,
+			},
+			"task_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the last AS3 task deploying the application",
+			},
+		},
+	}
+}
+
+func resourceBigipAs3ApplicationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	tenant, application, err := parseAs3ApplicationID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+	_ = d.Set("tenant", tenant)
+	_ = d.Set("application", application)
+	d.SetId(as3ApplicationID(tenant, application))
+	return []*schema.ResourceData{d}, nil
+}
+
+func as3ApplicationID(tenant, application string) string {
+	return fmt.Sprintf("/%s/%s", tenant, application)
+}
+
+// parseAs3ApplicationID splits an ID of the form /tenant/application, the leading slash being optional.
+func parseAs3ApplicationID(id string) (string, string, error) {
+	parts := strings.Split(strings.TrimPrefix(id, "/"), "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("invalid AS3 application ID (%s), expected /tenant/application", id)
+	}
+	return parts[0], parts[1], nil
+}
+
+// as3DeclarationApplications returns the names of the applications of a per-application declaration.
+func as3DeclarationApplications(declaration map[string]interface{}) []string {
+	var applications []string
+	for key, value := range declaration {
+		if _, ok := value.(map[string]interface{}); ok && !as3DeclarationProperties[key] {
+			applications = append(applications, key)
+		}
+	}
+	return applications
+}
+
+func resourceBigipAs3ApplicationCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("as3_json") || !d.NewValueKnown("application") {
+		return nil
+	}
+	var declaration map[string]interface{}
+	if err := json.Unmarshal([]byte(d.Get("as3_json").(string)), &declaration); err != nil {
+		return nil
+	}
+	if _, ok := declaration["class"]; ok {
+		return fmt.Errorf("as3_json must be a per-application declaration without a class, got a %v declaration", declaration["class"])
+	}
+	application := d.Get("application").(string)
+	applications := as3DeclarationApplications(declaration)
+	if len(applications) != 1 || applications[0] != application {
+		return fmt.Errorf("as3_json must declare the application (%s) only, found: %s", application, strings.Join(applications, ", "))
+	}
+	return nil
+}
+
+func resourceBigipAs3ApplicationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	tenant := d.Get("tenant").(string)
+	application := d.Get("application").(string)
+
+	perApplication, err := client.CheckSetting()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !perApplication {
+		return diag.FromErr(fmt.Errorf("per-application deployments are not allowed on the BIG-IP, set perAppDeploymentAllowed in the AS3 settings"))
+	}
+
+	log.Printf("[INFO] Creating AS3 application %s in tenant %s", application, tenant)
+	if diags := deployAs3Application(ctx, d, client); diags.HasError() {
+		return diags
+	}
+	d.SetId(as3ApplicationID(tenant, application))
+	return resourceBigipAs3ApplicationRead(ctx, d, meta)
+}
+
+func deployAs3Application(ctx context.Context, d *schema.ResourceData, client *bigip.BigIP) diag.Diagnostics {
+	tenant := d.Get("tenant").(string)
+	defer lockAs3Tenants(client, tenant)()
+	taskID, err := deployAs3Applications(ctx, client, d.Get("as3_json").(string), tenant, "")
+	if taskID != "" {
+		_ = d.Set("task_id", taskID)
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deploying AS3 application (%s) in tenant (%s): %v", d.Get("application").(string), tenant, err))
+	}
+	return nil
+}
+
+func resourceBigipAs3ApplicationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	tenant, application, err := parseAs3ApplicationID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading AS3 application %s in tenant %s", application, tenant)
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "get",
+		URL:         fmt.Sprintf("%s/%s/applications/%s", as3DeclareURL, tenant, application),
+		ContentType: "application/json",
+	})
+	if err != nil {
+		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] AS3 application (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading AS3 application (%s): %v", d.Id(), err))
+	}
+	as3Json, err := as3ApplicationDeclaration(resp, application, d.Get("as3_json").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if as3Json == "" {
+		log.Printf("[WARN] AS3 application (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("tenant", tenant)
+	_ = d.Set("application", application)
+	_ = d.Set("as3_json", as3Json)
+	return nil
+}
+
+// as3ApplicationDeclaration returns the per-application declaration of the application from an AS3 response,
+// or an empty string when the application is not part of it. AS3 does not return the declaration properties
+// of an application, such as controls, label and remark, so those of the configured declaration are kept.
+func as3ApplicationDeclaration(resp []byte, application, configured string) (string, error) {
+	var declaration map[string]interface{}
+	if err := json.Unmarshal(resp, &declaration); err != nil {
+		return "", fmt.Errorf("error parsing AS3 application (%s): %v", application, err)
+	}
+	app, ok := declaration[application].(map[string]interface{})
+	if !ok {
+		return "", nil
+	}
+	result := map[string]interface{}{
+		"schemaVersion": declaration["schemaVersion"],
+		application:     app,
+	}
+	var previous map[string]interface{}
+	if err := json.Unmarshal([]byte(configured), &previous); err == nil {
+		for key, value := range previous {
+			if as3DeclarationProperties[key] && key != "schemaVersion" {
+				result[key] = value
+			}
+		}
+	}
+	out, err := json.Marshal(result)
+	return string(out), err
+}
+
+func resourceBigipAs3ApplicationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Updating AS3 application %s", d.Id())
+	if diags := deployAs3Application(ctx, d, client); diags.HasError() {
+		return diags
+	}
+	return resourceBigipAs3ApplicationRead(ctx, d, meta)
+}
+
+func resourceBigipAs3ApplicationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	tenant, application, err := parseAs3ApplicationID(d.Id())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Deleting AS3 application %s in tenant %s", application, tenant)
+	defer lockAs3Tenants(client, tenant)()
+	if err := client.DeletePerApplicationAs3Bigip(tenant, application); err != nil {
+		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] AS3 application (%s) already deleted", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error deleting AS3 application (%s): %v", d.Id(), err))
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+var TestAs3ApplicationResource = `
+resource "bigip_as3_application" "path_app1" {
+  tenant      = "Shared_Team_Tenant"
+  application = "path_app1"
+  as3_json    = file("` + dir + `/../examples/as3/as3_per_app_example1.json")
+}
+`
+
+var TestAs3ApplicationMismatchResource = `
+resource "bigip_as3_application" "other_app" {
+  tenant      = "Shared_Team_Tenant"
+  application = "other_app"
+  as3_json    = file("` + dir + `/../examples/as3/as3_per_app_example1.json")
+}
+`
+
+func TestParseAs3ApplicationID(t *testing.T) {
+	for _, id := range []string{"/Tenant/App", "Tenant/App"} {
+		tenant, application, err := parseAs3ApplicationID(id)
+		assert.NoError(t, err)
+		assert.Equal(t, "Tenant", tenant)
+		assert.Equal(t, "App", application)
+	}
+	for _, id := range []string{"Tenant", "/Tenant/", "/Tenant/App/extra"} {
+		_, _, err := parseAs3ApplicationID(id)
+		assert.Error(t, err, id)
+	}
+}
+
+func TestAs3ApplicationDeclaration(t *testing.T) {
+	resp := `{"schemaVersion": "3.50.0", "id": "autogen_1", "app1": {"class": "Application", "pool": {"class": "Pool"}}, "app2": {"class": "Application"}}`
+	declaration, err := as3ApplicationDeclaration([]byte(resp), "app1", "")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"schemaVersion": "3.50.0", "app1": {"class": "Application", "pool": {"class": "Pool"}}}`, declaration)
+
+	declaration, err = as3ApplicationDeclaration([]byte(resp), "app3", "")
+	assert.NoError(t, err)
+	assert.Empty(t, declaration)
+}
+
+func TestResourceBigipAs3ApplicationReadPlan(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/shared/appsvcs/declare/Tenant/applications/app1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"schemaVersion": "3.50.0", "id": "autogen_1", "app1": {"class": "Application", "pool": {"class": "Pool"}}}`)
+	})
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+	configured := `{
+  "schemaVersion": "3.50.0",
+  "label": "team app",
+  "remark": "managed by Terraform",
+  "controls": {"class": "Controls", "logLevel": "debug", "trace": true},
+  "app1": {"class": "Application", "pool": {"class": "Pool"}}
+}`
+	as3Application := resourceBigipAs3Application()
+	d := schema.TestResourceDataRaw(t, as3Application.Schema, map[string]interface{}{
+		"tenant":      "Tenant",
+		"application": "app1",
+		"as3_json":    configured,
+	})
+	d.SetId("/Tenant/app1")
+
+	assert.False(t, resourceBigipAs3ApplicationRead(context.Background(), d, client).HasError())
+	assert.Equal(t, "/Tenant/app1", d.Id())
+	// the plan compares the declaration read back with the configured one
+	suppress := as3Application.Schema["as3_json"].DiffSuppressFunc
+	assert.True(t, suppress("as3_json", d.Get("as3_json").(string), configured, nil))
+	assert.False(t, suppress("as3_json", d.Get("as3_json").(string), strings.Replace(configured, `"class": "Pool"`, `"class": "Pool", "slowRampTime": 30`, 1), nil))
+}
+
+func TestAccBigipAs3Application_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAs3ApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      TestAs3ApplicationMismatchResource,
+				ExpectError: regexp.MustCompile("as3_json must declare the application \\(other_app\\) only"),
+			},
+			{
+				Config: TestAs3ApplicationResource,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_as3_application.path_app1", "id", "/Shared_Team_Tenant/path_app1"),
+					resource.TestCheckResourceAttrSet("bigip_as3_application.path_app1", "task_id"),
+				),
+			},
+			{
+				ResourceName:            "bigip_as3_application.path_app1",
+				ImportState:             true,
+				ImportStateId:           "Shared_Team_Tenant/path_app1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"as3_json", "task_id"},
+			},
+		},
+	})
+}
+
+func testCheckAs3ApplicationDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_as3_application" {
+			continue
+		}
+		tenant, application, err := parseAs3ApplicationID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		_, err = client.APICall(&bigip.APIRequest{
+			Method:      "get",
+			URL:         fmt.Sprintf("%s/%s/applications/%s", as3DeclareURL, tenant, application),
+			ContentType: "application/json",
+		})
+		if err == nil {
+			return fmt.Errorf("AS3 application %s still exists", rs.Primary.ID)
+		}
+		if !strings.Contains(err.Error(), "404") && !strings.Contains(err.Error(), "not found") {
+			return err
+		}
+	}
+	return nil
+}
@@ -29,6 +29,12 @@ resource "bigip_as3"  "as3-example" {
      as3_json = "${file("` + dir + `/../examples/as3/example1.json")}"
 }
 `
+var TestAs3PreviewResource = `
+resource "bigip_as3"  "as3-preview-example" {
+     as3_json        = "${file("` + dir + `/../examples/as3/example1.json")}"
+     preview_changes = true
+}
+`
 var TestAs3Resource1 = `
 resource "bigip_as3"  "as3-multitenant-example" {
      as3_json = "${file("` + dir + `/../examples/as3/as3_example1.json")}"
@@ -201,6 +207,34 @@ func TestAccBigipAs3_create_SingleTenant(t *testing.T) {
 	})
 }
 
+func TestAccBigipAs3_previewChanges(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAs3Destroy,
+		Steps: []resource.TestStep{
+			{
+				Config: TestAs3PreviewResource,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAs3Exists("Sample_new", true),
+					resource.TestCheckResourceAttr("bigip_as3.as3-preview-example", "preview_changes", "true"),
+					// the state matches the plan, which previewed the created objects
+					resource.TestCheckResourceAttrSet("bigip_as3.as3-preview-example", "planned_changes.0.path"),
+				),
+			},
+			{
+				Config: TestAs3PreviewResource,
+				Check: resource.ComposeTestCheckFunc(
+					// the preview of the plan is emptied by the next refresh
+					resource.TestCheckResourceAttr("bigip_as3.as3-preview-example", "planned_changes.#", "0"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccBigipAs3_create_MultiTenants(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -0,0 +1,368 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const packageManagementTasksURL = "/mgmt/shared/iapp/package-management-tasks"
+
+var atcPackagePollInterval = 3 * time.Second
+
+type atcPackageType struct {
+	name    string
+	infoURL string
+}
+
+// atcPackageTypes are the ATC services by type, with the name of their RPM and the endpoint answering once
+// the service is ready.
+var atcPackageTypes = map[string]atcPackageType{
+	"as3":  {name: "f5-appsvcs", infoURL: "/mgmt/shared/appsvcs/info"},
+	"do":   {name: "f5-declarative-onboarding", infoURL: doDeclareURL + "/info"},
+	"fast": {name: "f5-appsvcs-templates", infoURL: "/mgmt/shared/fast/info"},
+	"ts":   {name: "f5-telemetry", infoURL: tsInfoURL},
+}
+
+// atcRpmName matches ATC RPM file names such as f5-appsvcs-3.50.0-5.noarch.rpm.
+var atcRpmName = regexp.MustCompile(`^(.+)-([0-9]+(?:\.[0-9]+)*)-([0-9]+)\.noarch\.rpm$`)
+
+func resourceBigipAtcPackage() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipAtcPackageCreate,
+		ReadContext:   resourceBigipAtcPackageRead,
+		UpdateContext: resourceBigipAtcPackageUpdate,
+		DeleteContext: resourceBigipAtcPackageDelete,
+		CustomizeDiff: resourceBigipAtcPackageCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBigipAtcPackageImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ATC service of the package, one of as3, do, fast or ts",
+				ValidateFunc: validation.StringInSlice([]string{"as3", "do", "fast", "ts"}, false),
+			},
+			"rpm_path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Path of the local RPM file to install, named like f5-appsvcs-3.50.0-5.noarch.rpm",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Version of the package, e.g. 3.50.0. When set, it must be the version of rpm_path",
+			},
+			"package_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full name of the installed package, e.g. f5-appsvcs-3.50.0-5.noarch",
+			},
+		},
+	}
+}
+
+// parseAtcRpm returns the package name and the version of an ATC RPM file from its name.
+func parseAtcRpm(path string) (string, string, error) {
+	match := atcRpmName.FindStringSubmatch(filepath.Base(path))
+	if match == nil {
+		return "", "", fmt.Errorf("unable to read the package version from %s, expected a name like f5-appsvcs-3.50.0-5.noarch.rpm", filepath.Base(path))
+	}
+	return match[1], match[2], nil
+}
+
+// compareAtcVersions compares dotted versions, returning -1, 0 or 1.
+func compareAtcVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		var x, y int
+		if i < len(as) {
+			x, _ = strconv.Atoi(as[i])
+		}
+		if i < len(bs) {
+			y, _ = strconv.Atoi(bs[i])
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func resourceBigipAtcPackageCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("rpm_path") || !d.NewValueKnown("type") {
+		return nil
+	}
+	name, version, err := parseAtcRpm(d.Get("rpm_path").(string))
+	if err != nil {
+		return err
+	}
+	if expected := atcPackageTypes[d.Get("type").(string)].name; name != expected {
+		return fmt.Errorf("rpm_path is a %s package, expected %s for type %s", name, expected, d.Get("type").(string))
+	}
+	if raw := d.GetRawConfig(); raw.IsKnown() && !raw.IsNull() {
+		if pinned := raw.GetAttr("version"); pinned.IsKnown() && !pinned.IsNull() && pinned.AsString() != version {
+			return fmt.Errorf("version is pinned to %s but rpm_path is version %s", pinned.AsString(), version)
+		}
+	}
+	if d.Get("version").(string) != version {
+		return d.SetNew("version", version)
+	}
+	return nil
+}
+
+func resourceBigipAtcPackageImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, ok := atcPackageTypes[d.Id()]; !ok {
+		return nil, fmt.Errorf("invalid ATC package ID (%s), expected as3, do, fast or ts", d.Id())
+	}
+	_ = d.Set("type", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceBigipAtcPackageCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	packageType := d.Get("type").(string)
+	log.Printf("[INFO] Installing %s package %s", packageType, d.Get("rpm_path").(string))
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	if err := installAtcPackage(ctx, client, packageType, d.Get("rpm_path").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("error installing %s package: %v", packageType, err))
+	}
+	d.SetId(packageType)
+	return resourceBigipAtcPackageRead(ctx, d, meta)
+}
+
+func resourceBigipAtcPackageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	packageType := d.Id()
+	log.Printf("[INFO] Reading %s package", packageType)
+	installed, err := queryAtcPackage(ctx, client, atcPackageTypes[packageType].name)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading %s package: %v", packageType, err))
+	}
+	if installed == nil {
+		log.Printf("[WARN] %s package not installed, removing from state", packageType)
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("type", packageType)
+	_ = d.Set("version", installed.Version)
+	_ = d.Set("package_name", installed.PackageName)
+	return nil
+}
+
+func resourceBigipAtcPackageUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	packageType := d.Id()
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
+	log.Printf("[INFO] Installing %s package %s", packageType, d.Get("rpm_path").(string))
+	if err := installAtcPackage(ctx, client, packageType, d.Get("rpm_path").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("error installing %s package: %v", packageType, err))
+	}
+	return resourceBigipAtcPackageRead(ctx, d, meta)
+}
+
+func resourceBigipAtcPackageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	log.Printf("[INFO] Uninstalling package %s", d.Get("package_name").(string))
+	if err := uninstallAtcPackage(ctx, client, d.Get("package_name").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("error uninstalling %s package: %v", d.Id(), err))
+	}
+	d.SetId("")
+	return nil
+}
+
+type atcPackage struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Release     string `json:"release"`
+	PackageName string `json:"packageName"`
+}
+
+type packageManagementTask struct {
+	ID            string       `json:"id"`
+	Status        string       `json:"status"`
+	ErrorMessage  string       `json:"errorMessage"`
+	QueryResponse []atcPackage `json:"queryResponse"`
+}
+
+// installAtcPackage uploads the RPM, installs it and waits for the service to answer on its info endpoint.
+// A newer installed version is uninstalled first, as the package manager upgrades in place but refuses
+// downgrades, and the upload is skipped when the version is already installed.
+func installAtcPackage(ctx context.Context, client *bigip.BigIP, packageType, rpmPath string) error {
+	_, version, err := parseAtcRpm(rpmPath)
+	if err != nil {
+		return err
+	}
+	installed, err := queryAtcPackage(ctx, client, atcPackageTypes[packageType].name)
+	if err != nil {
+		return err
+	}
+	switch {
+	case installed == nil:
+	case installed.Version == version:
+		log.Printf("[INFO] %s package %s is already installed", packageType, installed.PackageName)
+		return waitAtcService(ctx, client, atcPackageTypes[packageType].infoURL, version)
+	case compareAtcVersions(version, installed.Version) < 0:
+		log.Printf("[INFO] Downgrading %s package from %s to %s", packageType, installed.Version, version)
+		if err := uninstallAtcPackage(ctx, client, installed.PackageName); err != nil {
+			return err
+		}
+	}
+	f, err := os.Open(rpmPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	upload, err := client.UploadFile(f)
+	if err != nil {
+		return fmt.Errorf("error uploading %s: %v", rpmPath, err)
+	}
+	packageFilePath := upload.LocalFilePath
+	if packageFilePath == "" {
+		packageFilePath = "/var/config/rest/downloads/" + filepath.Base(rpmPath)
+	}
+	if _, err := runPackageManagementTask(ctx, client, map[string]string{"operation": "INSTALL", "packageFilePath": packageFilePath}); err != nil {
+		return err
+	}
+	return waitAtcService(ctx, client, atcPackageTypes[packageType].infoURL, version)
+}
+
+func uninstallAtcPackage(ctx context.Context, client *bigip.BigIP, packageName string) error {
+	_, err := runPackageManagementTask(ctx, client, map[string]string{"operation": "UNINSTALL", "packageName": packageName})
+	if err != nil && strings.Contains(err.Error(), "not installed") {
+		log.Printf("[WARN] Package %s already uninstalled", packageName)
+		return nil
+	}
+	return err
+}
+
+// queryAtcPackage returns the installed package of that name, or nil.
+func queryAtcPackage(ctx context.Context, client *bigip.BigIP, name string) (*atcPackage, error) {
+	task, err := runPackageManagementTask(ctx, client, map[string]string{"operation": "QUERY"})
+	if err != nil {
+		return nil, err
+	}
+	for i := range task.QueryResponse {
+		if task.QueryResponse[i].Name == name {
+			return &task.QueryResponse[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// runPackageManagementTask creates a package management task and polls it until it finished.
+func runPackageManagementTask(ctx context.Context, client *bigip.BigIP, operation map[string]string) (*packageManagementTask, error) {
+	body, _ := json.Marshal(operation)
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "post",
+		URL:         packageManagementTasksURL,
+		Body:        string(body),
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error creating %s package management task: %v", operation["operation"], err)
+	}
+	task := &packageManagementTask{}
+	if err := json.Unmarshal(resp, task); err != nil || task.ID == "" {
+		return nil, fmt.Errorf("unable to read the package management task from the response %s: %v", string(resp), err)
+	}
+	for {
+		resp, err := client.APICall(&bigip.APIRequest{
+			Method:      "get",
+			URL:         packageManagementTasksURL + "/" + task.ID,
+			ContentType: "application/json",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error reading package management task (%s): %v", task.ID, err)
+		}
+		if err := json.Unmarshal(resp, task); err != nil {
+			return nil, fmt.Errorf("error parsing package management task (%s): %v", task.ID, err)
+		}
+		switch task.Status {
+		case "FINISHED":
+			return task, nil
+		case "FAILED":
+			return task, fmt.Errorf("%s package management task failed: %s", operation["operation"], task.ErrorMessage)
+		}
+		select {
+		case <-ctx.Done():
+			return task, fmt.Errorf("timed out waiting for %s package management task (%s): %v", operation["operation"], task.ID, ctx.Err())
+		case <-time.After(atcPackagePollInterval):
+		}
+	}
+}
+
+// waitAtcService polls the info endpoint of the service until it answers with the version, as restnoded
+// restarts after an install.
+func waitAtcService(ctx context.Context, client *bigip.BigIP, infoURL, version string) error {
+	for {
+		resp, err := client.APICall(&bigip.APIRequest{
+			Method:      "get",
+			URL:         infoURL,
+			ContentType: "application/json",
+		})
+		if err == nil {
+			info := atcServiceInfo(resp)
+			if info == version || version == "" {
+				log.Printf("[INFO] %s answers with version %s", infoURL, info)
+				return nil
+			}
+			log.Printf("[DEBUG] %s answers with version %s, waiting for %s", infoURL, info, version)
+		} else {
+			log.Printf("[DEBUG] Waiting for %s: %v", infoURL, err)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for %s to answer with version %s: %v", infoURL, version, ctx.Err())
+		case <-time.After(atcPackagePollInterval):
+		}
+	}
+}
+
+// atcServiceInfo returns the version reported by an ATC info endpoint, which AS3 returns in a list on BIG-IQ.
+func atcServiceInfo(resp []byte) string {
+	var info struct {
+		Version string `json:"version"`
+	}
+	if json.Unmarshal(resp, &info) != nil {
+		var infos []struct {
+			Version string `json:"version"`
+		}
+		if json.Unmarshal(resp, &infos) == nil && len(infos) > 0 {
+			return infos[0].Version
+		}
+	}
+	return info.Version
+}
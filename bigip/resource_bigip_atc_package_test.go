@@ -0,0 +1,105 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAtcRpm(t *testing.T) {
+	name, version, err := parseAtcRpm("/tmp/rpms/f5-appsvcs-3.50.0-5.noarch.rpm")
+	assert.NoError(t, err)
+	assert.Equal(t, "f5-appsvcs", name)
+	assert.Equal(t, "3.50.0", version)
+
+	name, version, err = parseAtcRpm("f5-declarative-onboarding-1.40.0-8.noarch.rpm")
+	assert.NoError(t, err)
+	assert.Equal(t, "f5-declarative-onboarding", name)
+	assert.Equal(t, "1.40.0", version)
+
+	_, _, err = parseAtcRpm("as3.rpm")
+	assert.Error(t, err)
+
+	assert.Equal(t, -1, compareAtcVersions("3.9.0", "3.10.0"))
+	assert.Equal(t, 0, compareAtcVersions("3.50.0", "3.50.0"))
+	assert.Equal(t, 1, compareAtcVersions("3.50.1", "3.50"))
+}
+
+func TestInstallAtcPackage(t *testing.T) {
+	atcPackagePollInterval = time.Millisecond
+	defer func() { atcPackagePollInterval = 3 * time.Second }()
+	setup()
+	defer teardown()
+
+	rpm := filepath.Join(t.TempDir(), "f5-appsvcs-3.49.0-4.noarch.rpm")
+	assert.NoError(t, os.WriteFile(rpm, []byte("rpm"), 0600))
+
+	var lock sync.Mutex
+	installed := "3.50.0"
+	var operations []string
+	tasks := map[string]string{}
+	mux.HandleFunc("/mgmt/shared/file-transfer/uploads/f5-appsvcs-3.49.0-4.noarch.rpm", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"remainingByteCount": 0, "localFilePath": "/var/config/rest/downloads/f5-appsvcs-3.49.0-4.noarch.rpm"}`)
+	})
+	mux.HandleFunc("/mgmt/shared/iapp/package-management-tasks", func(w http.ResponseWriter, r *http.Request) {
+		var operation map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&operation)
+		lock.Lock()
+		defer lock.Unlock()
+		operations = append(operations, operation["operation"])
+		id := fmt.Sprintf("task-%d", len(operations))
+		switch operation["operation"] {
+		case "QUERY":
+			tasks[id] = fmt.Sprintf(`{"id": "%s", "status": "FINISHED", "queryResponse": [{"name": "f5-appsvcs", "version": "%s", "release": "5", "packageName": "f5-appsvcs-%s-5.noarch"}]}`, id, installed, installed)
+		case "UNINSTALL":
+			assert.Equal(t, "f5-appsvcs-3.50.0-5.noarch", operation["packageName"])
+			tasks[id] = fmt.Sprintf(`{"id": "%s", "status": "FINISHED"}`, id)
+		case "INSTALL":
+			assert.Equal(t, "/var/config/rest/downloads/f5-appsvcs-3.49.0-4.noarch.rpm", operation["packageFilePath"])
+			installed = "3.49.0"
+			tasks[id] = fmt.Sprintf(`{"id": "%s", "status": "FINISHED"}`, id)
+		}
+		_, _ = fmt.Fprintf(w, `{"id": "%s", "status": "CREATED"}`, id)
+	})
+	mux.HandleFunc("/mgmt/shared/iapp/package-management-tasks/", func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		_, _ = fmt.Fprint(w, tasks[strings.TrimPrefix(r.URL.Path, "/mgmt/shared/iapp/package-management-tasks/")])
+	})
+	var infos int
+	mux.HandleFunc("/mgmt/shared/appsvcs/info", func(w http.ResponseWriter, r *http.Request) {
+		if infos++; infos == 1 {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"version": "3.49.0", "release": "4"}`)
+	})
+
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+	assert.NoError(t, installAtcPackage(context.Background(), client, "as3", rpm))
+	// the newer version is uninstalled before the downgrade
+	assert.Equal(t, []string{"QUERY", "UNINSTALL", "INSTALL"}, operations)
+	assert.Equal(t, 2, infos)
+
+	// an installed version is not installed again
+	assert.NoError(t, installAtcPackage(context.Background(), client, "as3", rpm))
+	assert.Equal(t, []string{"QUERY", "UNINSTALL", "INSTALL", "QUERY"}, operations)
+}
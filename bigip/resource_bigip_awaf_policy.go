@@ -34,6 +34,7 @@ func resourceBigipAwafPolicy() *schema.Resource {
 		ReadContext:   resourceBigipAwafPolicyRead,
 		UpdateContext: resourceBigipAwafPolicyUpdate,
 		DeleteContext: resourceBigipAwafPolicyDelete,
+		CustomizeDiff: customizeDiffBigipCapability("bigip_waf_policy", bigipCapability{modules: []string{"asm"}}),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -6,21 +6,16 @@ If a copy of the MPL was not distributed with this file, You can obtain one at h
 package bigip
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
-	"encoding/json"
 	"fmt"
-	"io"
 	"log"
-	"math"
-	"net/http"
 	"strings"
 	"time"
 
 	bigip "github.com/efellowsbg/go-bigip"
 	"github.com/efellowsbg/go-bigip/f5teem"
 	uuid "github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
@@ -32,6 +27,11 @@ func resourceBigipDo() *schema.Resource {
 		ReadContext:   resourceBigipDoRead,
 		UpdateContext: resourceBigipDoUpdate,
 		DeleteContext: resourceBigipDoDelete,
+		CustomizeDiff: resourceBigipDoCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -45,12 +45,14 @@ func resourceBigipDo() *schema.Resource {
 					jsonString, _ := structure.NormalizeJsonString(v)
 					return jsonString
 				},
+				ValidateDiagFunc: validateDoJson,
 			},
 			"timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
 				Default:     20,
-				Description: "DO json",
+				Deprecated:  "use the timeouts block instead",
+				Description: "Minutes to wait for DO to apply the declaration, overrides the timeouts block when set",
 			},
 			"tenant_name": {
 				Type:        schema.TypeString,
@@ -79,6 +81,22 @@ func resourceBigipDo() *schema.Resource {
 				Sensitive:   true,
 				Description: "Password of  BIGIP host to be used for this resource",
 			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the last DO task, e.g. OK or ERROR",
+			},
+			"errors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Errors reported by the last DO task",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"reboot_required": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the BIG-IP must be rebooted for the declaration to take effect",
+			},
 			"bigip_token_auth": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -86,22 +104,20 @@ func resourceBigipDo() *schema.Resource {
 				Description: "Enable to use an external authentication source (LDAP, TACACS, etc)",
 				Default:     false,
 			},
+			"bigip_login_ref": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Login reference for token authentication of the BIG-IP set in bigip_address, defaults to the login_ref of the provider",
+			},
 		},
 	}
 }
 
 func resourceBigipDoCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clientBigip := meta.(*bigip.BigIP)
-
-	if d.Get("bigip_address").(string) != "" && d.Get("bigip_user").(string) != "" && d.Get("bigip_password").(string) != "" || d.Get("bigip_port").(string) != "" {
-		clientBigip2, err := connectBigIP(d)
-		if err != nil {
-			log.Printf("Connection to BIGIP Failed with :%v", err)
-			return diag.FromErr(err)
-		}
-		clientBigip = clientBigip2
+	clientBigip, err := doClient(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
 	}
-	doJson := d.Get("do_json").(string)
 	if !clientBigip.Teem {
 		id := uuid.New()
 		uniqueID := id.String()
@@ -119,402 +135,141 @@ func resourceBigipDoCreate(ctx context.Context, d *schema.ResourceData, meta int
 			log.Printf("[ERROR]Sending Telemetry data failed:%v", err)
 		}
 	}
-
-	timeout := d.Get("timeout").(int)
-	timeoutSec := timeout * 60
-	log.Printf("[DEBUG]timeout_sec is :%d", timeoutSec)
-	log.Printf("[INFO] Creating do config in bigip:%s", doJson)
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
-	client := &http.Client{Transport: tr}
-	url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/"
-	req, err := http.NewRequest("POST", url, strings.NewReader(doJson))
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while creating http request with DO json:%v", err))
+	log.Printf("[INFO] Creating DO config in bigip %s", clientBigip.Host)
+	if diags := deployDo(ctx, d, clientBigip, doTimeout(d, schema.TimeoutCreate)); diags.HasError() {
+		return diags
 	}
-	if clientBigip.Token != "" {
-		req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-	} else {
-		req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-
-	log.Printf("[INFO] URL:%s", clientBigip.Host)
-
-	resp, err := client.Do(req)
-
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Printf("[DEBUG] Could not close the request to %s", url)
-		}
-	}()
+	return resourceBigipDoRead(ctx, d, meta)
+}
 
+// deployDo posts do_json, or attaches to the DO task already processing it, and waits for DO to apply it.
+// The task ID is recorded as soon as the task is known, so that an apply interrupted while DO still runs
+// resumes waiting for the same task.
+func deployDo(ctx context.Context, d *schema.ResourceData, client *bigip.BigIP, timeout time.Duration) diag.Diagnostics {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	doJson := d.Get("do_json").(string)
+	task, err := findDoTask(client, doJson)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while receiving  http response with DO json:%v", err))
+		log.Printf("[WARN] Unable to list the DO tasks of %s: %v", client.Host, err)
 	}
-	// body, err := os.ReadAll(resp.Body)
-	var body bytes.Buffer
-	_, err = io.Copy(&body, resp.Body)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while reading http response with DO json:%v", err))
+	if task != nil {
+		log.Printf("[INFO] Attaching to DO task %s already processing the declaration", task.ID)
+	} else if task, err = postDoDeclaration(client, doJson); err != nil {
+		if task != nil {
+			setDoTaskState(d, task)
+		}
+		return diag.FromErr(fmt.Errorf("error onboarding BIG-IP (%s): %v", client.Host, err))
 	}
+	d.SetId(task.ID)
+	setDoTaskState(d, task)
 
-	if resp.StatusCode < 200 || resp.StatusCode > 202 {
-		return diag.FromErr(fmt.Errorf("Error while Sending/Posting http request with DO json :%s  %v", body.String(), err))
+	task, err = completeDoTask(ctx, client, task)
+	if task != nil {
+		setDoTaskState(d, task)
 	}
-	respRef := make(map[string]interface{})
-	if err := json.Unmarshal(body.Bytes(), &respRef); err != nil {
-		return diag.FromErr(err)
+	if err != nil {
+		if task != nil && task.pending() {
+			return diag.FromErr(fmt.Errorf("error onboarding BIG-IP (%s): %v; the DO task keeps running on the BIG-IP and the next apply waits for it", client.Host, err))
+		}
+		return diag.FromErr(fmt.Errorf("error onboarding BIG-IP (%s): %v", client.Host, err))
 	}
-	respID := respRef["id"].(string)
-
-	var doSuccess = false
+	return nil
+}
 
-	if resp.StatusCode == 200 {
-		log.Printf("[DEBUG] response status is 200 ok and no aysnc flag in declaration")
-		doSuccess = true
-		d.SetId(respID)
+// doTimeout returns the deprecated timeout attribute when it is configured, and the timeouts block otherwise.
+func doTimeout(d *schema.ResourceData, key string) time.Duration {
+	if raw := d.GetRawConfig(); raw.IsKnown() && !raw.IsNull() && !raw.GetAttr("timeout").IsNull() {
+		return time.Duration(d.Get("timeout").(int)) * time.Minute
 	}
+	return d.Timeout(key)
+}
 
-	if resp.StatusCode == http.StatusAccepted {
-		start := time.Now()
-	forLoop:
-		for time.Since(start).Seconds() < float64(timeoutSec) {
-			log.Printf("[DEBUG]Value of Timeout counter in seconds :%v", math.Ceil(time.Since(start).Seconds()))
-			url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/task/" + respID
-			req, _ := http.NewRequest("GET", url, nil)
-			if clientBigip.Token != "" {
-				req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-			} else {
-				req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-			}
-			req.Header.Set("Accept", "application/json")
-			req.Header.Set("Content-Type", "application/json")
-			taskResp, err := client.Do(req)
-			if taskResp == nil {
-				log.Printf("[DEBUG]taskResp of DO is empty,but continue the loop until timeout \n")
-				time.Sleep(1 * time.Second)
-				continue
-			}
-			defer taskResp.Body.Close()
-			if err != nil {
-				log.Printf("[DEBUG]Polling the task id until the timeout")
-				time.Sleep(1 * time.Second)
-				continue
-			}
-			switch {
-			case taskResp.StatusCode == 200:
-				var body bytes.Buffer
-				_, err = io.Copy(&body, taskResp.Body)
-				// respBody, err := ioutil.ReadAll(taskResp.Body)
-				if err != nil {
-					d.SetId("")
-					return diag.FromErr(fmt.Errorf("error while reading the response body :%v", err))
-				}
-				respRef1 := make(map[string]interface{})
-				if err := json.Unmarshal(body.Bytes(), &respRef1); err != nil {
-					return diag.FromErr(err)
-				}
-				log.Printf("[DEBUG] Got success and setting state id")
-				doSuccess = true
-				d.SetId(respID)
-				break forLoop
-			case taskResp.StatusCode == 202:
-				var respBody bytes.Buffer
-				_, err = io.Copy(&respBody, taskResp.Body)
-				// respBody, err := ioutil.ReadAll(taskResp.Body)
-				if err != nil {
-					d.SetId("")
-					return diag.FromErr(fmt.Errorf("error while reading the response body :%v", err))
-				}
-				respRef1 := make(map[string]interface{})
-				if err := json.Unmarshal(respBody.Bytes(), &respRef1); err != nil {
-					return diag.FromErr(err)
-				}
-				resultMap := respRef1["result"]
-				if resultMap.(map[string]interface{})["status"] != "RUNNING" {
-					return diag.FromErr(fmt.Errorf("error while reading the response body :%v", resultMap))
-				}
-			default:
-				log.Printf("StatusCode:%+v", taskResp.StatusCode)
-			}
-			time.Sleep(1 * time.Second)
-		}
+// resourceBigipDoCustomizeDiff plans an update while the last DO task is still running, so that the next
+// apply waits for it.
+func resourceBigipDoCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
 	}
-
-	if !doSuccess {
-		log.Printf("[DEBUG] Didn't get successful response within timeout")
-		url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/task/" + respID
-		req, _ := http.NewRequest("GET", url, nil)
-		if clientBigip.Token != "" {
-			req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-		} else {
-			req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-		}
-		req.Header.Set("Accept", "application/json")
-		req.Header.Set("Content-Type", "application/json")
-		taskResp, err := client.Do(req)
-		if taskResp == nil {
-			d.SetId("")
-			return diag.FromErr(fmt.Errorf("timedout while polling the DO task id with error :%v", err))
-		}
-		defer taskResp.Body.Close()
-		if err != nil {
-			d.SetId("")
-			return diag.FromErr(fmt.Errorf("timedout while polling the DO task id with error :%v", err))
-		}
-		var respBody bytes.Buffer
-		_, err = io.Copy(&respBody, taskResp.Body)
-		if err != nil {
-			d.SetId("")
-			return diag.FromErr(fmt.Errorf("timedout while polling the DO task id with error :%v", err))
-		}
-		respRef2 := make(map[string]interface{})
-		if err := json.Unmarshal(respBody.Bytes(), &respRef2); err != nil {
-			return diag.FromErr(err)
-		}
-		log.Printf("[DEBUG] timeout resp_body is :%v", respRef2)
-		resultMap := respRef2["result"]
-		d.SetId("")
-		return diag.FromErr(fmt.Errorf("timeout while polling the DO task id with result:%v", resultMap))
+	task := &doTask{Result: doTaskResult{Status: d.Get("status").(string)}}
+	if task.Result.Status != "" && task.pending() {
+		log.Printf("[INFO] DO task %s is still %s, planning to wait for it", d.Id(), task.Result.Status)
+		return d.SetNewComputed("status")
 	}
+	return nil
+}
 
-	return resourceBigipDoRead(ctx, d, meta)
+func setDoTaskState(d *schema.ResourceData, task *doTask) {
+	_ = d.Set("status", task.Result.Status)
+	_ = d.Set("errors", task.Result.Errors)
+	_ = d.Set("reboot_required", task.Result.RebootRequired)
 }
 
-func resourceBigipDoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clientBigip := meta.(*bigip.BigIP)
+// doClient returns the provider client, or a client for the BIG-IP set in the resource.
+func doClient(d *schema.ResourceData, meta interface{}) (*bigip.BigIP, error) {
 	if d.Get("bigip_address").(string) != "" && d.Get("bigip_user").(string) != "" && d.Get("bigip_password").(string) != "" || d.Get("bigip_port").(string) != "" {
-		clientBigip2, err := connectBigIP(d)
+		client, err := connectBigIP(d, meta)
 		if err != nil {
-			log.Printf("Connection to BIGIP Failed with :%v", err)
-			return diag.FromErr(err)
+			log.Printf("[ERROR] Connection to BIGIP Failed with :%v", err)
+			return nil, err
 		}
-		clientBigip = clientBigip2
+		return client, nil
 	}
-	log.Printf("[INFO] Reading Do config")
-	ID := d.Id()
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
-	client := &http.Client{Transport: tr}
-	url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/task/" + ID
-	req, err := http.NewRequest("GET", url, nil)
+	return meta.(*bigip.BigIP), nil
+}
+
+func resourceBigipDoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	clientBigip, err := doClient(d, meta)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while creating http request for reading Do config:%v", err))
+		return diag.FromErr(err)
 	}
-	if clientBigip.Token != "" {
-		req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
+	log.Printf("[INFO] Reading DO config of task %s", d.Id())
+	task, err := getDoTask(clientBigip, d.Id())
+	if err != nil {
+		if !isDoNotFound(err) {
+			return diag.FromErr(fmt.Errorf("error reading DO task (%s): %v", d.Id(), err))
+		}
+		// DO only keeps its tasks until restnoded restarts, the onboarded settings are still inspected.
+		log.Printf("[WARN] DO task (%s) not found, keeping its last status", d.Id())
 	} else {
-		req.SetBasicAuth(clientBigip.User, clientBigip.Password)
+		setDoTaskState(d, task)
 	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
 
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Printf("[DEBUG] Could not close the request to %s", url)
+	doJson := d.Get("do_json").(string)
+	if doJson == "" {
+		// on import, the declaration is the one of the task
+		if task == nil || len(task.Declaration) == 0 {
+			return diag.FromErr(fmt.Errorf("no declaration found for DO task (%s)", d.Id()))
 		}
-	}()
+		_ = d.Set("do_json", string(task.Declaration))
+		return nil
+	}
 
+	observed, err := inspectDo(clientBigip)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while receiving http response body in read call :%v ", err))
+		log.Printf("[WARN] Unable to inspect the onboarded configuration of %s, drift is not detected: %v", clientBigip.Host, err)
+		return nil
 	}
-	var respBody bytes.Buffer
-	_, err = io.Copy(&respBody, resp.Body)
-	// respBody, err := ioutil.ReadAll(resp.Body)
+	drifted, drift, err := doDeclarationDrift(doJson, observed)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while reading http response body in read call :%v ", err))
+		return diag.FromErr(fmt.Errorf("error comparing do_json with the BIG-IP configuration: %v", err))
 	}
-	bodyString := respBody.String()
-	if resp.Status != "200 OK" {
-		return diag.FromErr(fmt.Errorf("error while Sending/fetching http request :%s ", bodyString))
+	if len(drift) > 0 {
+		log.Printf("[INFO] DO settings drifted on %s: %s", clientBigip.Host, strings.Join(drift, ", "))
+		_ = d.Set("do_json", drifted)
 	}
-	respRef1 := make(map[string]interface{})
-	if err := json.Unmarshal(respBody.Bytes(), &respRef1); err != nil {
-		return diag.FromErr(err)
-	}
-	log.Printf("[INFO] in read resp_body is :%v", respRef1)
-	byteData, _ := json.Marshal(respRef1["declaration"])
-	_ = d.Set("do_json", string(byteData))
-
 	return nil
-
 }
 
 func resourceBigipDoUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clientBigip := meta.(*bigip.BigIP)
-	if d.Get("bigip_address").(string) != "" && d.Get("bigip_user").(string) != "" && d.Get("bigip_password").(string) != "" || d.Get("bigip_port").(string) != "" {
-		clientBigip2, err := connectBigIP(d)
-		if err != nil {
-			log.Printf("Connection to BIGIP Failed with :%v", err)
-			return diag.FromErr(err)
-		}
-		clientBigip = clientBigip2
-	}
-
-	doJson := d.Get("do_json").(string)
-	timeout := d.Get("timeout").(int)
-	timeoutSec := timeout * 60
-	log.Printf("[DEBUG]timeout_sec is :%d", timeoutSec)
-	log.Printf("[INFO] Updating do config in bigip:%s", doJson)
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
-	client := &http.Client{Transport: tr}
-	url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/"
-	req, err := http.NewRequest("POST", url, strings.NewReader(doJson))
+	clientBigip, err := doClient(d, meta)
 	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while creating http request with DO json:%v ", err))
-	}
-	if clientBigip.Token != "" {
-		req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-	} else {
-		req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-	}
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
-
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Printf("[DEBUG] Could not close the request to %s", url)
-		}
-	}()
-
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while receiving  http response with DO json:%v", err))
-	}
-	var body bytes.Buffer
-	_, err = io.Copy(&body, resp.Body)
-	// body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while reading http response with DO json:%v ", err))
-	}
-
-	if resp.StatusCode < 200 || resp.StatusCode > 202 {
-		return diag.FromErr(fmt.Errorf("error while Sending/Posting http request with DO json :%s  %v", body.String(), err))
-	}
-	respRef := make(map[string]interface{})
-	if err := json.Unmarshal(body.Bytes(), &respRef); err != nil {
 		return diag.FromErr(err)
 	}
-	respID := respRef["id"].(string)
-
-	var doSuccess = false
-
-	if resp.StatusCode == 200 {
-		log.Printf("[DEBUG] response status is 200 ok and no aysnc flag in declaration")
-		doSuccess = true
-		d.SetId(respID)
+	log.Printf("[INFO] Updating DO config in bigip %s", clientBigip.Host)
+	if diags := deployDo(ctx, d, clientBigip, doTimeout(d, schema.TimeoutUpdate)); diags.HasError() {
+		return diags
 	}
-
-	if resp.StatusCode == http.StatusAccepted {
-		start := time.Now()
-	forLoop:
-		for time.Since(start).Seconds() < float64(timeoutSec) {
-			log.Printf("[DEBUG]Value of Timeout counter in seconds :%v", math.Ceil(time.Since(start).Seconds()))
-			url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/task/" + respID
-			req, _ := http.NewRequest("GET", url, nil)
-			if clientBigip.Token != "" {
-				req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-			} else {
-				req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-			}
-			req.Header.Set("Accept", "application/json")
-			req.Header.Set("Content-Type", "application/json")
-			taskResp, err := client.Do(req)
-
-			defer func() {
-				if err := taskResp.Body.Close(); err != nil {
-					log.Printf("[DEBUG] Could not close the request to %s", url)
-				}
-			}()
-
-			if err != nil {
-				log.Printf("[DEBUG]Polling the task id until the timeout")
-				time.Sleep(1 * time.Second)
-				continue
-			}
-			switch {
-			case taskResp.StatusCode == 200:
-				var respBody bytes.Buffer
-				_, err = io.Copy(&respBody, taskResp.Body)
-				// respBody, err := ioutil.ReadAll(taskResp.Body)
-				if err != nil {
-					d.SetId("")
-					return diag.FromErr(fmt.Errorf("error while reading the response body :%v", err))
-				}
-				respRef1 := make(map[string]interface{})
-				if err := json.Unmarshal(respBody.Bytes(), &respRef1); err != nil {
-					return diag.FromErr(err)
-				}
-				doSuccess = true
-				d.SetId(respID)
-				break forLoop
-			case taskResp.StatusCode == 202:
-				var respBody bytes.Buffer
-				_, err = io.Copy(&respBody, taskResp.Body)
-				// respBody, err := ioutil.ReadAll(taskResp.Body)
-				if err != nil {
-					d.SetId("")
-					return diag.FromErr(fmt.Errorf("error while reading the response body :%v", err))
-				}
-				respRef1 := make(map[string]interface{})
-				if err := json.Unmarshal(respBody.Bytes(), &respRef1); err != nil {
-					return diag.FromErr(err)
-				}
-				resultMap := respRef1["result"]
-				if resultMap.(map[string]interface{})["status"] != "RUNNING" {
-					return diag.FromErr(fmt.Errorf("error while reading the response body :%v", resultMap))
-				}
-			default:
-				log.Printf("StatusCode:%+v", taskResp.StatusCode)
-			}
-			time.Sleep(1 * time.Second)
-		}
-	}
-
-	if !doSuccess {
-		log.Printf("[DEBUG] Didn't get successful response within timeout")
-		url := clientBigip.Host + "/mgmt/shared/declarative-onboarding/task/" + respID
-		req, _ := http.NewRequest("GET", url, nil)
-		if clientBigip.Token != "" {
-			req.Header.Set("X-F5-Auth-Token", clientBigip.Token)
-		} else {
-			req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-		}
-		req.Header.Set("Accept", "application/json")
-		req.Header.Set("Content-Type", "application/json")
-		taskResp, err := client.Do(req)
-
-		defer func() {
-			if err := taskResp.Body.Close(); err != nil {
-				log.Printf("[DEBUG] Could not close the request to %s", url)
-			}
-		}()
-
-		if err != nil {
-			d.SetId("")
-			return diag.FromErr(fmt.Errorf("Timedout while polling the DO task id with error :%v ", err))
-		}
-		var respBody bytes.Buffer
-		_, err = io.Copy(&respBody, taskResp.Body)
-		// respBody, err := ioutil.ReadAll(taskResp.Body)
-		if err != nil {
-			d.SetId("")
-			return diag.FromErr(fmt.Errorf("Timedout while polling the DO task id with error :%v ", err))
-		}
-		respRef2 := make(map[string]interface{})
-		if err := json.Unmarshal(respBody.Bytes(), &respRef2); err != nil {
-			return diag.FromErr(err)
-		}
-
-		resultMap := respRef2["result"]
-		d.SetId("")
-		return diag.FromErr(fmt.Errorf("timeout while polling the DO task id with result:%v", resultMap))
-	}
-
 	return resourceBigipDoRead(ctx, d, meta)
 }
 
@@ -525,24 +280,43 @@ func resourceBigipDoDelete(ctx context.Context, d *schema.ResourceData, meta int
 	return nil
 }
 
-func connectBigIP(d *schema.ResourceData) (*bigip.BigIP, error) {
+// connectBigIP connects to the BIG-IP set in the resource, with the TLS settings and login reference of the provider.
+func connectBigIP(d *schema.ResourceData, meta interface{}) (*bigip.BigIP, error) {
 	var portVal string
 	if _, ok := d.GetOk("bigip_port"); ok {
 		portVal = d.Get("bigip_port").(string)
 	} else {
 		portVal = "443"
 	}
+	provider := providerConnectionOf(meta)
 	bigipConfig := bigip.Config{
-		Address:           d.Get("bigip_address").(string),
-		Port:              portVal,
-		Username:          d.Get("bigip_user").(string),
-		Password:          d.Get("bigip_password").(string),
-		CertVerifyDisable: true,
+		Address:            d.Get("bigip_address").(string),
+		Port:               portVal,
+		Username:           d.Get("bigip_user").(string),
+		Password:           d.Get("bigip_password").(string),
+		CertVerifyDisable:  provider.certVerifyDisable,
+		TrustedCertificate: provider.trustedCertificate,
+		ConfigOptions:      provider.configOptions,
 	}
 
 	if d.Get("bigip_token_auth").(bool) {
-		bigipConfig.LoginReference = d.Get("bigiq_login_ref").(string)
+		bigipConfig.LoginReference = provider.loginReference
+		if loginRef := d.Get("bigip_login_ref").(string); loginRef != "" {
+			bigipConfig.LoginReference = loginRef
+		}
 	}
 
 	return Client(&bigipConfig)
 }
+
+// validateDoJson validates do_json against the embedded Declarative Onboarding schema.
+func validateDoJson(v interface{}, path cty.Path) diag.Diagnostics {
+	if _, err := structure.NormalizeJsonString(v); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("do_json contains an invalid JSON: %s", err),
+			AttributePath: path,
+		}}
+	}
+	return validateDeclaration(doDeclarationSchemas, v.(string), path)
+}
@@ -7,10 +7,18 @@ If a copy of the MPL was not distributed with this file, You can obtain one at h
 package bigip
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
 	"regexp"
 	"testing"
+	"time"
 
+	bigip "github.com/efellowsbg/go-bigip"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccBigipDeclarativeOnboardTCs(t *testing.T) {
@@ -35,3 +43,53 @@ func TestAccBigipDeclarativeOnboardTCs(t *testing.T) {
 		},
 	})
 }
+
+func TestConnectBigIP(t *testing.T) {
+	setup()
+	defer teardown()
+	var loginProvider string
+	mux.HandleFunc("/mgmt/shared/authn/login", func(w http.ResponseWriter, r *http.Request) {
+		var login struct {
+			LoginProviderName string `json:"loginProviderName"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&login))
+		loginProvider = login.LoginProviderName
+		_, _ = fmt.Fprint(w, `{"token": {"token": "TOKEN1"}, "timeout": {"timeout": 1200}}`)
+	})
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"items": []}`)
+	})
+
+	address, _ := url.Parse(server.URL)
+	provider := &bigip.BigIP{Host: "bigip.example.com"}
+	options := &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, TokenTimeout: 1200 * time.Second, APICallRetries: 1}
+	raw := map[string]interface{}{
+		"do_json":          "{}",
+		"bigip_address":    "http://" + address.Hostname(),
+		"bigip_port":       address.Port(),
+		"bigip_user":       "admin",
+		"bigip_password":   "secret",
+		"bigip_token_auth": true,
+	}
+
+	// the login reference of the provider is used by default
+	setProviderConnection(provider, providerConnection{certVerifyDisable: true, loginReference: "ldap", configOptions: options})
+	client, err := connectBigIP(schema.TestResourceDataRaw(t, resourceBigipDo().Schema, raw), provider)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "ldap", loginProvider)
+		assert.Equal(t, "TOKEN1", client.Token)
+		assert.True(t, client.Transport.TLSClientConfig.InsecureSkipVerify)
+	}
+
+	raw["bigip_login_ref"] = "tacacs"
+	_, err = connectBigIP(schema.TestResourceDataRaw(t, resourceBigipDo().Schema, raw), provider)
+	assert.NoError(t, err)
+	assert.Equal(t, "tacacs", loginProvider)
+
+	// certificates are verified with the trusted certificate of the provider
+	setProviderConnection(provider, providerConnection{certVerifyDisable: false, trustedCertificate: "testdata/missing.crt", configOptions: options})
+	_, err = connectBigIP(schema.TestResourceDataRaw(t, resourceBigipDo().Schema, raw), provider)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "provide Valid Trusted certificate path")
+	}
+}
@@ -7,36 +7,41 @@ If a copy of the MPL was not distributed with this file,You can obtain one at ht
 package bigip
 
 import (
-	"bytes"
 	"context"
-	"crypto/tls"
+	"encoding/json"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	bigip "github.com/efellowsbg/go-bigip"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const serviceDiscoveryTaskURL = "/mgmt/shared/service-discovery/task"
+
 func resourceServiceDiscovery() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceServiceDiscoveryCreate,
 		ReadContext:   resourceServiceDiscoveryRead,
 		UpdateContext: resourceServiceDiscoveryUpdate,
 		DeleteContext: resourceServiceDiscoveryDelete,
+		CustomizeDiff: resourceServiceDiscoveryCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 
 			"taskid": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of the partition/tenant",
-				ForceNew:    true,
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "ID of the event service discovery task, ~tenant~application~pool",
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(~[^~/]+){3}$`), "must be ~tenant~application~pool, e.g. ~Sample_event_sd~My_app~My_pool"),
 			},
 			"node": {
 				Type:     schema.TypeSet,
@@ -49,14 +54,62 @@ func resourceServiceDiscovery() *schema.Resource {
 							Description: "name of node",
 						},
 						"ip": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "ip of nonde",
+							ValidateFunc: validation.IsIPAddress,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "port",
+							ValidateFunc: validation.IsPortNumber,
+						},
+					},
+				},
+			},
+			"added_nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the nodes added to the task by the last apply",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"removed_nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the nodes removed from the task by the last apply",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"member": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Members of the pool of the task, with their health",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
 							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "ip of nonde",
+							Computed:    true,
+							Description: "Name of the pool member",
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Address of the pool member",
 						},
 						"port": {
 							Type:        schema.TypeInt,
-							Optional:    true,
-							Description: "port",
+							Computed:    true,
+							Description: "Port of the pool member",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Monitor state of the pool member, e.g. up, down or unchecked",
+						},
+						"session": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Session state of the pool member, e.g. monitor-enabled or user-disabled",
 						},
 					},
 				},
@@ -69,17 +122,8 @@ func resourceServiceDiscoveryCreate(ctx context.Context, d *schema.ResourceData,
 	client := meta.(*bigip.BigIP)
 	taskid := d.Get("taskid").(string)
 	log.Printf("[INFO]: taskid: %+v", taskid)
-	var nodeList []interface{}
-	if m, ok := d.GetOk("node"); ok {
-		for _, node := range m.(*schema.Set).List() {
-			log.Printf("[INFO]: node Value: %+v", node)
-			nodeList = append(nodeList, node)
-		}
-	}
-	log.Printf("[INFO]: node Value: %+v", nodeList)
-	err := client.AddServiceDiscoveryNodes(taskid, nodeList)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error modifying node %s: %v", nodeList, err))
+	if err := reconcileServiceDiscoveryNodes(d, client, taskid); err != nil {
+		return diag.FromErr(err)
 	}
 	d.SetId(taskid)
 	return resourceServiceDiscoveryRead(ctx, d, meta)
@@ -90,21 +134,25 @@ func resourceServiceDiscoveryRead(ctx context.Context, d *schema.ResourceData, m
 	client := meta.(*bigip.BigIP)
 	taskid := d.Id()
 
-	serviceDiscoveryResp, err := client.GetServiceDiscoveryNodes(taskid)
-	log.Printf("[DEBUG] serviceDiscoveryResp is :%v", serviceDiscoveryResp)
+	task, err := getEventDiscoveryTask(client, taskid)
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error Reading node : %v", err))
 	}
-	nodeList1 := serviceDiscoveryResp.(map[string]interface{})["result"].(map[string]interface{})["providerOptions"].(map[string]interface{})["nodeList"]
-	log.Printf("[DEBUG] nodeList1 is :%v", nodeList1)
-
-	if serviceDiscoveryResp == nil {
+	if task == nil {
+		log.Printf("[WARN] Service discovery task (%s) not found, removing from state", taskid)
 		d.SetId("")
-		return diag.FromErr(fmt.Errorf("[DEBUG]serviceDiscoveryResp is : %s", serviceDiscoveryResp))
+		return nil
 	}
-	if err := d.Set("node", nodeList1); err != nil {
+	log.Printf("[DEBUG] nodeList is :%+v", task.ProviderOptions.NodeList)
+	_ = d.Set("taskid", taskid)
+	if err := d.Set("node", flattenServiceDiscoveryNodes(task.ProviderOptions.NodeList)); err != nil {
 		return diag.FromErr(fmt.Errorf("error updating nodelist in state: %v", err))
 	}
+	members, err := getEventDiscoveryMembers(client, task)
+	if err != nil {
+		log.Printf("[WARN] Unable to read the pool members of service discovery task %s: %v", taskid, err)
+	}
+	_ = d.Set("member", members)
 	return nil
 }
 
@@ -112,58 +160,200 @@ func resourceServiceDiscoveryUpdate(ctx context.Context, d *schema.ResourceData,
 	client := meta.(*bigip.BigIP)
 	taskid := d.Id()
 	log.Printf("[INFO]: taskid: %+v", taskid)
-	var nodeList []interface{}
-	if m, ok := d.GetOk("node"); ok {
-		for _, node := range m.(*schema.Set).List() {
-			log.Printf("[INFO]: node Value: %+v", node)
-			nodeList = append(nodeList, node)
-		}
-	}
-	err := client.AddServiceDiscoveryNodes(taskid, nodeList)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error modifying node %s: %v", nodeList, err))
+	if err := reconcileServiceDiscoveryNodes(d, client, taskid); err != nil {
+		return diag.FromErr(err)
 	}
 	return resourceServiceDiscoveryRead(ctx, d, meta)
 }
 
 func resourceServiceDiscoveryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	clientBigip := meta.(*bigip.BigIP)
+	client := meta.(*bigip.BigIP)
 	taskid := d.Id()
-	url := clientBigip.Host + "/mgmt/shared/service-discovery/task/" + taskid + "/nodes/"
-	payload := strings.NewReader("[ ]\n")
-	log.Printf("[DEBUG] url Complete :%v", url)
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
-	client := &http.Client{Transport: tr}
-	req, err := http.NewRequest("POST", url, payload)
-	if err != nil {
-		return diag.FromErr(fmt.Errorf("error while creating http request for Delete operation:%+v ", err))
-	}
-	req.SetBasicAuth(clientBigip.User, clientBigip.Password)
-	req.Header.Set("Accept", "application/json")
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
+	defer lockAs3Tenants(client, eventDiscoveryTenant(taskid))()
+	task, err := getEventDiscoveryTask(client, taskid)
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Printf("[DEBUG] Could not close the request to %s", url)
+	if task != nil && len(task.ProviderOptions.NodeList) > 0 {
+		if err := client.AddServiceDiscoveryNodes(taskid, []interface{}{}); err != nil {
+			return diag.FromErr(fmt.Errorf("error while removing the nodes of task %s: %v", taskid, err))
 		}
-	}()
+	}
+	d.SetId("")
+	return nil
+}
 
-	var body bytes.Buffer
-	_, err = io.Copy(&body, resp.Body)
-	// body, err := ioutil.ReadAll(resp.Body)
+// resourceServiceDiscoveryCustomizeDiff marks the added and removed nodes unknown when the nodes change.
+func resourceServiceDiscoveryCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.HasChange("node") {
+		if err := d.SetNewComputed("added_nodes"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("removed_nodes")
+	}
+	return nil
+}
+
+// eventDiscoveryTask is a service discovery task created by AS3 for a pool member of addressDiscovery event.
+type eventDiscoveryTask struct {
+	ID              string `json:"id"`
+	Provider        string `json:"provider"`
+	ProviderOptions struct {
+		NodeList []serviceDiscoveryNode `json:"nodeList"`
+	} `json:"providerOptions"`
+	Resources []struct {
+		Type string `json:"type"`
+		Path string `json:"path"`
+	} `json:"resources"`
+}
+
+// getEventDiscoveryTask returns a service discovery task, nil when it does not exist.
+func getEventDiscoveryTask(client *bigip.BigIP, taskid string) (*eventDiscoveryTask, error) {
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "get",
+		URL:         serviceDiscoveryTaskURL + "/" + taskid,
+		ContentType: "application/json",
+	})
 	if err != nil {
-		return diag.FromErr(err)
+		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading service discovery task %s: %v", taskid, err)
 	}
+	// the task is wrapped in a result, as for its nodes
+	var wrapped struct {
+		Result *eventDiscoveryTask `json:"result"`
+	}
+	if err := json.Unmarshal(resp, &wrapped); err != nil {
+		return nil, fmt.Errorf("error parsing service discovery task %s: %v", taskid, err)
+	}
+	if wrapped.Result != nil {
+		return wrapped.Result, nil
+	}
+	var task eventDiscoveryTask
+	if err := json.Unmarshal(resp, &task); err != nil {
+		return nil, fmt.Errorf("error parsing service discovery task %s: %v", taskid, err)
+	}
+	return &task, nil
+}
 
-	bodyString := body.String()
-	if resp.Status != "200 OK" {
-		return diag.FromErr(fmt.Errorf("error while Sending/Posting http request for Delete operation :%s  %v", bodyString, err))
+// eventDiscoveryTenant returns the tenant of a task id, ~tenant~application~pool.
+func eventDiscoveryTenant(taskid string) string {
+	return strings.Split(strings.TrimPrefix(taskid, "~"), "~")[0]
+}
+
+// reconcileServiceDiscoveryNodes sets the nodes of the task to the configured nodes, and the added and
+// removed nodes. The task is only updated when its nodes differ, so that applying the same nodes again,
+// e.g. on repeated autoscaling events, does not touch the pool.
+func reconcileServiceDiscoveryNodes(d *schema.ResourceData, client *bigip.BigIP, taskid string) error {
+	var desired []serviceDiscoveryNode
+	if m, ok := d.GetOk("node"); ok {
+		for _, node := range m.(*schema.Set).List() {
+			n := node.(map[string]interface{})
+			desired = append(desired, serviceDiscoveryNode{ID: n["id"].(string), IP: n["ip"].(string), Port: n["port"].(int)})
+		}
+	}
+	if err := validateServiceDiscoveryNodes(desired); err != nil {
+		return err
 	}
 
-	d.SetId("")
+	defer lockAs3Tenants(client, eventDiscoveryTenant(taskid))()
+	task, err := getEventDiscoveryTask(client, taskid)
+	if err != nil {
+		return err
+	}
+	if task == nil {
+		return fmt.Errorf("service discovery task %s not found: it is created by an AS3 declaration with a pool member of addressDiscovery event", taskid)
+	}
+	if task.Provider != "event" {
+		return fmt.Errorf("service discovery task %s has provider %s, expected event", taskid, task.Provider)
+	}
+	added, removed := diffServiceDiscoveryNodes(task.ProviderOptions.NodeList, desired)
+	_ = d.Set("added_nodes", added)
+	_ = d.Set("removed_nodes", removed)
+	if len(added) == 0 && len(removed) == 0 {
+		log.Printf("[DEBUG] Service discovery task %s already holds the nodes", taskid)
+		return nil
+	}
+	log.Printf("[INFO] Updating service discovery task %s: adding %v, removing %v", taskid, added, removed)
+	nodeList := make([]interface{}, 0, len(desired))
+	for _, node := range desired {
+		nodeList = append(nodeList, map[string]interface{}{"id": node.ID, "ip": node.IP, "port": node.Port})
+	}
+	if err := client.AddServiceDiscoveryNodes(taskid, nodeList); err != nil {
+		return fmt.Errorf("error modifying node %s: %v", nodeList, err)
+	}
 	return nil
 }
+
+// diffServiceDiscoveryNodes returns the sorted ids of the nodes added and removed going from current to
+// desired. A node whose address or port changes is removed and added.
+func diffServiceDiscoveryNodes(current, desired []serviceDiscoveryNode) ([]string, []string) {
+	before := make(map[string]serviceDiscoveryNode)
+	for _, node := range current {
+		before[node.ID] = node
+	}
+	after := make(map[string]serviceDiscoveryNode)
+	for _, node := range desired {
+		after[node.ID] = node
+	}
+	added, removed := []string{}, []string{}
+	for id, node := range after {
+		if old, ok := before[id]; !ok || old != node {
+			added = append(added, id)
+		}
+	}
+	for id, node := range before {
+		if now, ok := after[id]; !ok || now != node {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// getEventDiscoveryMembers returns the members of the pool of a task with their health.
+func getEventDiscoveryMembers(client *bigip.BigIP, task *eventDiscoveryTask) ([]interface{}, error) {
+	pool := strings.ReplaceAll(task.ID, "~", "/")
+	for _, resource := range task.Resources {
+		if resource.Type == "pool" && resource.Path != "" {
+			pool = resource.Path
+		}
+	}
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "get",
+		URL:         "/mgmt/tm/ltm/pool/" + strings.ReplaceAll(pool, "/", "~") + "/members",
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var members struct {
+		Items []struct {
+			Name    string `json:"name"`
+			Address string `json:"address"`
+			State   string `json:"state"`
+			Session string `json:"session"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(resp, &members); err != nil {
+		return nil, err
+	}
+	list := make([]interface{}, 0, len(members.Items))
+	for _, member := range members.Items {
+		// members are named address:port, or address.port for IPv6
+		port := 0
+		if i := strings.LastIndexAny(member.Name, ":."); i >= 0 {
+			port, _ = strconv.Atoi(member.Name[i+1:])
+		}
+		list = append(list, map[string]interface{}{
+			"name":    member.Name,
+			"address": member.Address,
+			"port":    port,
+			"state":   member.State,
+			"session": member.Session,
+		})
+	}
+	return list, nil
+}
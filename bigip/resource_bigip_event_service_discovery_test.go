@@ -0,0 +1,95 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffServiceDiscoveryNodes(t *testing.T) {
+	current := []serviceDiscoveryNode{{ID: "n1", IP: "10.1.1.1", Port: 80}, {ID: "n2", IP: "10.1.1.2", Port: 80}}
+	added, removed := diffServiceDiscoveryNodes(current, []serviceDiscoveryNode{{ID: "n2", IP: "10.1.1.2", Port: 80}, {ID: "n1", IP: "10.1.1.1", Port: 80}})
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+
+	added, removed = diffServiceDiscoveryNodes(current, []serviceDiscoveryNode{{ID: "n2", IP: "10.1.1.2", Port: 8080}, {ID: "n3", IP: "10.1.1.3", Port: 80}})
+	assert.Equal(t, []string{"n2", "n3"}, added)
+	assert.Equal(t, []string{"n1", "n2"}, removed)
+}
+
+func TestResourceServiceDiscoveryReconcile(t *testing.T) {
+	setup()
+	defer teardown()
+	taskNodes := `[{"id": "n1", "ip": "10.1.1.1", "port": 80}]`
+	posts := 0
+	mux.HandleFunc("/mgmt/shared/service-discovery/task/~T1~App~Pool", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"code": 200, "message": "success", "result": {"id": "~T1~App~Pool", "provider": "event",
+			"providerOptions": {"nodeList": %s}, "resources": [{"type": "pool", "path": "/T1/App/Pool"}]}}`, taskNodes)
+	})
+	mux.HandleFunc("/mgmt/shared/service-discovery/task/~T1~App~Pool/nodes", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		posts++
+		var nodes []serviceDiscoveryNode
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&nodes))
+		out, _ := json.Marshal(nodes)
+		taskNodes = string(out)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"code": 200, "message": "success"}`)
+	})
+	mux.HandleFunc("/mgmt/shared/service-discovery/task/~T1~App~Static", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"code": 200, "message": "success", "result": {"id": "~T1~App~Static", "provider": "consul"}}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~T1~App~Pool/members", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"items": [{"name": "10.1.1.1:80", "address": "10.1.1.1", "state": "up", "session": "monitor-enabled"},
+			{"name": "fd00::2.80", "address": "fd00::2", "state": "down", "session": "monitor-enabled"}]}`)
+	})
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+	nodes := []interface{}{
+		map[string]interface{}{"id": "n1", "ip": "10.1.1.1", "port": 80},
+		map[string]interface{}{"id": "n2", "ip": "10.1.1.2", "port": 80},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceServiceDiscovery().Schema, map[string]interface{}{"taskid": "~T1~App~Pool", "node": nodes})
+	diags := resourceServiceDiscoveryCreate(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 1, posts)
+	assert.Equal(t, []interface{}{"n2"}, d.Get("added_nodes"))
+	assert.Empty(t, d.Get("removed_nodes"))
+	assert.Equal(t, 2, d.Get("node.#"))
+	assert.Equal(t, "up", d.Get("member.0.state"))
+	assert.Equal(t, 80, d.Get("member.1.port"))
+
+	d = schema.TestResourceDataRaw(t, resourceServiceDiscovery().Schema, map[string]interface{}{"taskid": "~T1~App~Pool", "node": nodes})
+	diags = resourceServiceDiscoveryCreate(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 1, posts, "the same nodes are not posted again")
+	assert.Empty(t, d.Get("added_nodes"))
+
+	d = schema.TestResourceDataRaw(t, resourceServiceDiscovery().Schema, map[string]interface{}{"taskid": "~T1~App~Static", "node": nodes})
+	diags = resourceServiceDiscoveryCreate(context.Background(), d, client)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "has provider consul, expected event")
+
+	d = schema.TestResourceDataRaw(t, resourceServiceDiscovery().Schema, map[string]interface{}{"taskid": "~T1~App~Missing", "node": nodes})
+	diags = resourceServiceDiscoveryCreate(context.Background(), d, client)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "service discovery task ~T1~App~Missing not found")
+}
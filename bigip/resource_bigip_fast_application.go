@@ -17,6 +17,7 @@ import (
 	bigip "github.com/efellowsbg/go-bigip"
 	"github.com/efellowsbg/go-bigip/f5teem"
 	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
@@ -29,6 +30,7 @@ func resourceBigipFastApp() *schema.Resource {
 		UpdateContext: resourceBigipFastAppUpdate,
 		DeleteContext: resourceBigipFastAppDelete,
 		Exists:        resourceBigipFastAppExists,
+		CustomizeDiff: resourceBigipFastAppCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -95,8 +97,13 @@ func resourceBigipFastAppCreate(ctx context.Context, d *schema.ResourceData, met
 	client := meta.(*bigip.BigIP)
 	fastTmpl := d.Get("template").(string)
 	fastJson := d.Get("fast_json").(string)
-	m.Lock()
-	defer m.Unlock()
+	// templates installed by the same apply are only known to FAST now
+	if fastTmpl != "" {
+		if diags := validateFastApplicationParams(client, fastTmpl, fastJson, cty.GetAttrPath("fast_json")); diags.HasError() {
+			return diags
+		}
+	}
+	defer lockAs3Tenants(client, fastJsonTenant(fastJson))()
 	log.Printf("[INFO] Creating FastApp config")
 	userAgent := fmt.Sprintf("?userAgent=%s/%s", client.UserAgent, fastTmpl)
 	tenant, app, err := client.PostFastAppBigip(fastJson, fastTmpl, userAgent)
@@ -180,8 +187,12 @@ func resourceBigipFastAppExists(d *schema.ResourceData, meta interface{}) (bool,
 func resourceBigipFastAppUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
 	fastJson := d.Get("fast_json").(string)
-	m.Lock()
-	defer m.Unlock()
+	if template := d.Get("template").(string); template != "" {
+		if diags := validateFastApplicationParams(client, template, fastJson, cty.GetAttrPath("fast_json")); diags.HasError() {
+			return diags
+		}
+	}
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 	log.Printf("[INFO] Updating FastApp Config :%s", fastJson)
 	name := d.Id()
 	tenant := d.Get("tenant").(string)
@@ -194,8 +205,7 @@ func resourceBigipFastAppUpdate(ctx context.Context, d *schema.ResourceData, met
 
 func resourceBigipFastAppDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 	name := d.Id()
 	tenant := d.Get("tenant").(string)
 	err := client.DeleteFastAppBigip(tenant, name)
@@ -205,3 +215,29 @@ func resourceBigipFastAppDelete(ctx context.Context, d *schema.ResourceData, met
 	d.SetId("")
 	return nil
 }
+
+// resourceBigipFastAppCustomizeDiff validates fast_json against the parameter schema of the template, so
+// that missing parameters, wrong types and values out of an enum fail the plan instead of the FAST task.
+func resourceBigipFastAppCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	template := d.Get("template").(string)
+	if template == "" || !d.NewValueKnown("template") || !d.NewValueKnown("fast_json") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("fast_json") && !d.HasChange("template") {
+		return nil
+	}
+	client, ok := meta.(*bigip.BigIP)
+	if !ok || client == nil {
+		return nil
+	}
+	path := cty.GetAttrPath("fast_json")
+	return fastApplicationParamsError(validateFastApplicationParams(client, template, d.Get("fast_json").(string), path), path)
+}
+
+// fastJsonTenant returns the tenant_name parameter of the FAST application, used to lock its AS3 tenant.
+func fastJsonTenant(fastJson string) string {
+	var params map[string]interface{}
+	_ = json.Unmarshal([]byte(fastJson), &params)
+	tenant, _ := params["tenant_name"].(string)
+	return tenant
+}
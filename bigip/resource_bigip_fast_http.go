@@ -30,6 +30,7 @@ func resourceBigipHttpFastApp() *schema.Resource {
 		ReadContext:   resourceBigipFastHttpAppRead,
 		UpdateContext: resourceBigipFastHttpAppUpdate,
 		DeleteContext: resourceBigipFastHttpAppDelete,
+		CustomizeDiff: fastAppRenderedAs3Diff(fastTmpl, getFastHttpConfig),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -263,6 +264,11 @@ func resourceBigipHttpFastApp() *schema.Resource {
 				Computed:    true,
 				Description: "Json payload for FAST HTTP application.",
 			},
+			"rendered_as3": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "AS3 declaration rendered by FAST from the parameters of the application.",
+			},
 		},
 	}
 }
@@ -273,8 +279,7 @@ func resourceBigipFastHttpAppCreate(ctx context.Context, d *schema.ResourceData,
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 	log.Printf("[INFO] Creating HTTP FastApp config")
 	userAgent := fmt.Sprintf("?userAgent=%s/%s", client.UserAgent, fastTmpl)
 	tenant, app, err := client.PostFastAppBigip(fastJson, fastTmpl, userAgent)
@@ -360,8 +365,7 @@ func resourceBigipFastHttpAppUpdate(ctx context.Context, d *schema.ResourceData,
 	if e != nil {
 		return diag.FromErr(e)
 	}
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 	log.Printf("[INFO] Updating FastApp Config :%s", fastJson)
 	name := d.Id()
 	tenant := d.Get("tenant").(string)
@@ -374,8 +378,7 @@ func resourceBigipFastHttpAppUpdate(ctx context.Context, d *schema.ResourceData,
 
 func resourceBigipFastHttpAppDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 	name := d.Id()
 	tenant := d.Get("tenant").(string)
 	err := client.DeleteFastAppBigip(tenant, name)
@@ -514,7 +517,7 @@ func flattenFastPoolMembers(members []bigip.FastHttpPool) []interface{} {
 	return att
 }
 
-func getFastHttpConfig(d *schema.ResourceData) (string, error) {
+func getFastHttpConfig(d fastAppConfig) (string, error) {
 	httpJson := &bigip.FastHttpJson{
 		Tenant:      d.Get("tenant").(string),
 		Application: d.Get("application").(string),
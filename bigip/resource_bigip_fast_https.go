@@ -28,6 +28,7 @@ func resourceBigipFastHTTPSApp() *schema.Resource {
 		ReadContext:   resourceBigipFastHTTPSAppRead,
 		UpdateContext: resourceBigipFastHTTPSAppUpdate,
 		DeleteContext: resourceBigipFastHTTPSAppDelete,
+		CustomizeDiff: fastAppRenderedAs3Diff(fastTmpl, getFastHTTPSConfig),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -312,6 +313,11 @@ func resourceBigipFastHTTPSApp() *schema.Resource {
 				Computed:    true,
 				Description: "Json payload for FAST HTTPS application.",
 			},
+			"rendered_as3": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "AS3 declaration rendered by FAST from the parameters of the application.",
+			},
 		},
 	}
 }
@@ -322,8 +328,7 @@ func resourceBigipFastHTTPSAppCreate(ctx context.Context, d *schema.ResourceData
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 	log.Printf("[INFO] Creating HTTPS FastApp config")
 	userAgent := fmt.Sprintf("?userAgent=%s/%s", client.UserAgent, fastTmpl)
 	tenant, app, err := client.PostFastAppBigip(fastJson, fastTmpl, userAgent)
@@ -408,8 +413,7 @@ func resourceBigipFastHTTPSAppUpdate(ctx context.Context, d *schema.ResourceData
 	if e != nil {
 		return diag.FromErr(e)
 	}
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 	log.Printf("[INFO] Updating FastApp Config :%s", fastJson)
 	name := d.Id()
 	tenant := d.Get("tenant").(string)
@@ -422,8 +426,7 @@ func resourceBigipFastHTTPSAppUpdate(ctx context.Context, d *schema.ResourceData
 
 func resourceBigipFastHTTPSAppDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 	name := d.Id()
 	tenant := d.Get("tenant").(string)
 	err := client.DeleteFastAppBigip(tenant, name)
@@ -487,7 +490,7 @@ func flattenFastTlsServerProfile(data bigip.FastHttpJson) map[string]interface{}
 	return tfMap
 }
 
-func getFastHTTPSConfig(d *schema.ResourceData) (string, error) {
+func getFastHTTPSConfig(d fastAppConfig) (string, error) {
 	httpJson := &bigip.FastHttpJson{
 		Tenant:      d.Get("tenant").(string),
 		Application: d.Get("application").(string),
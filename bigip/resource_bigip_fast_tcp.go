@@ -28,6 +28,7 @@ func resourceBigipFastTcpApp() *schema.Resource {
 		ReadContext:   resourceBigipFastTcpAppRead,
 		UpdateContext: resourceBigipFastTcpAppUpdate,
 		DeleteContext: resourceBigipFastTcpAppDelete,
+		CustomizeDiff: fastAppRenderedAs3Diff("bigip-fast-templates/tcp", getParamsConfigMap),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -195,6 +196,11 @@ func resourceBigipFastTcpApp() *schema.Resource {
 				Computed:    true,
 				Description: "Json payload for FAST TCP application.",
 			},
+			"rendered_as3": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "AS3 declaration rendered by FAST from the parameters of the application.",
+			},
 		},
 	}
 }
@@ -202,8 +208,7 @@ func resourceBigipFastTcpApp() *schema.Resource {
 func resourceBigipFastTcpAppCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
 	const templateName string = "bigip-fast-templates/tcp"
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 
 	log.Printf("[INFO] Creating FAST TCP Application")
 
@@ -292,8 +297,7 @@ func resourceBigipFastTcpAppRead(ctx context.Context, d *schema.ResourceData, me
 
 func resourceBigipFastTcpAppUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 
 	cfg, err := getParamsConfigMap(d)
 	log.Printf("[INFO] Updating FastApp Config :%v", cfg)
@@ -313,8 +317,7 @@ func resourceBigipFastTcpAppUpdate(ctx context.Context, d *schema.ResourceData,
 
 func resourceBigipFastTcpAppDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 	name := d.Id()
 	tenant := d.Get("tenant").(string)
 	err := client.DeleteFastAppBigip(tenant, name)
@@ -358,7 +361,7 @@ func setFastTcpData(d *schema.ResourceData, data bigip.FastTCPJson) error {
 	return nil
 }
 
-func getParamsConfigMap(d *schema.ResourceData) (string, error) {
+func getParamsConfigMap(d fastAppConfig) (string, error) {
 	// paramConfig := make(map[string]interface{})
 	tcpJson := &bigip.FastTCPJson{
 		Tenant:      d.Get("tenant").(string),
@@ -21,6 +21,7 @@ func resourceBigipFastTemplate() *schema.Resource {
 		ReadContext:   resourceBigipFastRead,
 		UpdateContext: resourceBigipFastUpdate,
 		DeleteContext: resourceBigipFastDelete,
+		CustomizeDiff: resourceBigipFastTemplateCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -33,16 +34,34 @@ func resourceBigipFastTemplate() *schema.Resource {
 				Description: "Name of Fast template set",
 			},
 			"source": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "Location of the fast template set package on disk",
+				ExactlyOneOf: []string{"source", "files", "directory"},
+			},
+			"files": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Files of the template set by name, such as YAML or mustache templates, packaged by the provider",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"directory": {
 				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Location of the fast template set package on disk",
+				Optional:    true,
+				Description: "Directory holding the files of the template set, packaged by the provider",
 			},
 			"md5_hash": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				Description: "MD5 hash of the fast template zip file",
 			},
+			"content_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SHA-256 hash of the files of the template set packaged by the provider",
+			},
 		},
 	}
 }
@@ -60,21 +79,29 @@ func resourceBigipFastCreate(ctx context.Context, d *schema.ResourceData, meta i
 	}
 
 	log.Println("[INFO] Creating Fast Template Name " + name)
-	log.Println("[INFO] Reading provided archive " + tmplName)
+	var err error
+	if tmplPath != "" {
+		log.Println("[INFO] Reading provided archive " + tmplName)
 
-	file, fail := os.OpenFile(tmplPath, os.O_RDWR, 0644)
-	if fail != nil {
-		return diag.FromErr(fmt.Errorf("error in reading file: %s", fail))
-	}
+		file, fail := os.OpenFile(tmplPath, os.O_RDWR, 0644)
+		if fail != nil {
+			return diag.FromErr(fmt.Errorf("error in reading file: %s", fail))
+		}
 
-	err := client.UploadFastTemplate(file, name)
+		err = client.UploadFastTemplate(file, name)
 
-	defer file.Close()
+		defer file.Close()
+	} else {
+		err = uploadFastTemplateFiles(d, client, name)
+	}
 
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error in creating FAST template set (%s): %s", name, err))
 	}
-	_ = d.Set("md5_hash", checksum)
+	invalidateFastTemplateSchemas(client, name)
+	if tmplPath != "" {
+		_ = d.Set("md5_hash", checksum)
+	}
 	d.SetId(name)
 	if !client.Teem {
 		id := uuid.New()
@@ -109,6 +136,11 @@ func resourceBigipFastRead(ctx context.Context, d *schema.ResourceData, meta int
 		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Fast Template Set content: %+v", template)
+	if template == nil {
+		log.Printf("[WARN] Fast Template Set (%s) not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
 	_ = d.Set("name", template.Name)
 	_ = d.Set("md5_hash", checksum)
 
@@ -128,6 +160,77 @@ func resourceBigipFastDelete(ctx context.Context, d *schema.ResourceData, meta i
 		log.Printf("[ERROR] Unable to Delete Fast Template Set   (%s) (%v) ", name, err)
 		return diag.FromErr(err)
 	}
+	invalidateFastTemplateSchemas(client, name)
 	d.SetId("")
 	return nil
 }
+
+// fastTemplateFiles returns the files of the template set given by files or directory.
+func fastTemplateFiles(files map[string]interface{}, directory string) (map[string]string, error) {
+	if directory != "" {
+		return readFastTemplateDirectory(directory)
+	}
+	set := make(map[string]string, len(files))
+	for name, content := range files {
+		set[name] = content.(string)
+	}
+	return set, nil
+}
+
+// resourceBigipFastTemplateCustomizeDiff validates the files of the template set and plans a new upload when
+// their content changes, as the files of a directory are not part of the configuration.
+func resourceBigipFastTemplateCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("source").(string) != "" || !d.NewValueKnown("files") || !d.NewValueKnown("directory") || !d.NewValueKnown("name") {
+		return nil
+	}
+	if d.Get("name").(string) == "" {
+		return fmt.Errorf("name is required to package the template set of files or directory")
+	}
+	files, err := fastTemplateFiles(d.Get("files").(map[string]interface{}), d.Get("directory").(string))
+	if err != nil {
+		return err
+	}
+	if err := validateFastTemplateSet(files); err != nil {
+		return err
+	}
+	contentHash, md5Hash, err := fastTemplateSetHashes(files)
+	if err != nil {
+		return err
+	}
+	if d.Get("content_hash").(string) != contentHash {
+		if err := d.SetNew("content_hash", contentHash); err != nil {
+			return err
+		}
+		return d.SetNew("md5_hash", md5Hash)
+	}
+	return nil
+}
+
+// uploadFastTemplateFiles packages the files or the directory of the template set and installs it.
+func uploadFastTemplateFiles(d *schema.ResourceData, client *bigip.BigIP, name string) error {
+	files, err := fastTemplateFiles(d.Get("files").(map[string]interface{}), d.Get("directory").(string))
+	if err != nil {
+		return err
+	}
+	if err := validateFastTemplateSet(files); err != nil {
+		return err
+	}
+	archive, err := packageFastTemplateSet(files)
+	if err != nil {
+		return err
+	}
+	contentHash, md5Hash, err := fastTemplateSetHashes(files)
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Uploading %d files of Fast Template Set %s", len(files), name)
+	if _, err := client.UploadBytes(archive, name+".zip"); err != nil {
+		return err
+	}
+	if err := client.AddTemplateSet(&bigip.FastTemplateSet{Name: name}); err != nil {
+		return err
+	}
+	_ = d.Set("content_hash", contentHash)
+	_ = d.Set("md5_hash", md5Hash)
+	return nil
+}
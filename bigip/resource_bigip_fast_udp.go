@@ -27,6 +27,7 @@ func resourceBigipFastUdpApp() *schema.Resource {
 		ReadContext:   resourceBigipFastUdpAppRead,
 		UpdateContext: resourceBigipFastUdpAppUpdate,
 		DeleteContext: resourceBigipFastUdpAppDelete,
+		CustomizeDiff: fastAppRenderedAs3Diff("bigip-fast-templates/udp", getParamsConfigMapUdp),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -237,6 +238,11 @@ func resourceBigipFastUdpApp() *schema.Resource {
 				Computed:    true,
 				Description: "Json payload for FAST UDP application.",
 			},
+			"rendered_as3": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "AS3 declaration rendered by FAST from the parameters of the application.",
+			},
 		},
 	}
 }
@@ -244,8 +250,7 @@ func resourceBigipFastUdpApp() *schema.Resource {
 func resourceBigipFastUdpAppCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
 	const templateName string = "bigip-fast-templates/udp"
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 
 	log.Printf("[INFO] Creating FAST UDP Application")
 
@@ -324,8 +329,7 @@ func resourceBigipFastUdpAppRead(ctx context.Context, d *schema.ResourceData, me
 
 func resourceBigipFastUdpAppUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 
 	name := d.Get("application").(string)
 	tenant := d.Get("tenant").(string)
@@ -344,8 +348,7 @@ func resourceBigipFastUdpAppUpdate(ctx context.Context, d *schema.ResourceData,
 
 func resourceBigipFastUdpAppDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
-	m.Lock()
-	defer m.Unlock()
+	defer lockAs3Tenants(client, d.Get("tenant").(string))()
 	name := d.Id()
 	tenant := d.Get("tenant").(string)
 	err := client.DeleteFastAppBigip(tenant, name)
@@ -401,7 +404,7 @@ func setFastUdpData(d *schema.ResourceData, data bigip.FastUDPJson) error {
 	return nil
 }
 
-func getParamsConfigMapUdp(d *schema.ResourceData) (string, error) {
+func getParamsConfigMapUdp(d fastAppConfig) (string, error) {
 	udpJson := &bigip.FastUDPJson{
 		Tenant:      d.Get("tenant").(string),
 		Application: d.Get("application").(string),
@@ -574,6 +577,5 @@ func getParamsConfigMapUdp(d *schema.ResourceData) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	_ = d.Set("fast_udp_json", string(data))
 	return string(data), nil
 }
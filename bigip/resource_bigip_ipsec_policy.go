@@ -26,6 +26,7 @@ func resourceBigipIpsecPolicy() *schema.Resource {
 		ReadContext:   resourceBigipIpsecPolicyRead,
 		UpdateContext: resourceBigipIpsecPolicyUpdate,
 		DeleteContext: resourceBigipIpsecPolicyDelete,
+		CustomizeDiff: customizeDiffBigipCapability("bigip_ipsec_policy", bigipCapability{modules: []string{"ltm"}}),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -21,6 +21,7 @@ func resourceBigipIpsecProfile() *schema.Resource {
 		ReadContext:   resourceBigipIpsecProfileRead,
 		UpdateContext: resourceBigipIpsecProfileUpdate,
 		DeleteContext: resourceBigipIpsecProfileDelete,
+		CustomizeDiff: customizeDiffBigipCapability("bigip_ipsec_profile", bigipCapability{modules: []string{"ltm"}}),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
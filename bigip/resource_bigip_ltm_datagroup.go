@@ -25,6 +25,7 @@ func resourceBigipLtmDataGroup() *schema.Resource {
 		ReadContext:   resourceBigipLtmDataGroupRead,
 		UpdateContext: resourceBigipLtmDataGroupUpdate,
 		DeleteContext: resourceBigipLtmDataGroupDelete,
+		CustomizeDiff: customizeDiffIRuleObject(iruleReferenceDataGroup, plannedName),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -234,6 +235,9 @@ func resourceBigipLtmDataGroupDelete(ctx context.Context, d *schema.ResourceData
 
 	name := d.Id()
 	log.Printf("[DEBUG] Deleting Data Group List %s", name)
+	if err := checkIRuleReferencesTo(ctx, client, iruleReferenceDataGroup, name); err != nil {
+		return diag.FromErr(err)
+	}
 	if d.Get("internal").(bool) {
 		err := client.DeleteInternalDataGroup(name)
 		if err != nil {
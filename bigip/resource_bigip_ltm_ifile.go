@@ -16,6 +16,7 @@ func resourceBigipLtmIfile() *schema.Resource {
 		ReadContext:   resourceBigipLtmIfileRead,
 		UpdateContext: resourceBigipLtmIfileUpdate,
 		DeleteContext: resourceBigipLtmIfileDelete,
+		CustomizeDiff: customizeDiffIRuleObject(iruleReferenceIFile, plannedIFileFullPath, "file_name"),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -135,6 +136,9 @@ func resourceBigipLtmIfileDelete(ctx context.Context, d *schema.ResourceData, me
 	fullPath := d.Id()
 
 	log.Printf("[INFO] Deleting LTM iFile: %+v", fullPath)
+	if err := checkIRuleReferencesTo(ctx, client, iruleReferenceIFile, fullPath); err != nil {
+		return diag.FromErr(err)
+	}
 
 	err := client.DeleteLtmIFile(fullPath)
 	if err != nil {
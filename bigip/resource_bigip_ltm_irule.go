@@ -10,11 +10,13 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"strings"
 
 	bigip "github.com/efellowsbg/go-bigip"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceBigipLtmIRule() *schema.Resource {
@@ -23,6 +25,7 @@ func resourceBigipLtmIRule() *schema.Resource {
 		ReadContext:   resourceBigipLtmIRuleRead,
 		UpdateContext: resourceBigipLtmIRuleUpdate,
 		DeleteContext: resourceBigipLtmIRuleDelete,
+		CustomizeDiff: resourceBigipLtmIRuleCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -43,6 +46,36 @@ func resourceBigipLtmIRule() *schema.Resource {
 				StateFunc: func(s interface{}) string {
 					return strings.TrimSpace(s.(string))
 				},
+				ValidateDiagFunc: validateIRuleDiag,
+			},
+
+			"strict_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Fail the plan when the iRule validation reports warnings",
+			},
+
+			"reference_validation": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "warn",
+				Description:  "What to do when a data group, pool or iFile referenced by the iRule does not exist, one of warn, error or none",
+				ValidateFunc: validation.StringInSlice([]string{"warn", "error", "none"}, false),
+			},
+
+			"references": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Data groups, pools and iFiles referenced by name from the iRule",
+				Elem:        iruleReferenceSchema(),
+			},
+
+			"reference_warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "References that do not exist, or that the plan destroys, with reference_validation warn",
+				Elem:        &schema.Schema{Type: schema.TypeString},
 			},
 		},
 	}
@@ -53,6 +86,7 @@ func resourceBigipLtmIRuleCreate(ctx context.Context, d *schema.ResourceData, me
 
 	name := d.Get("name").(string)
 	log.Printf("[INFO] Creating iRule %s", name)
+	forgetPlannedIRuleObjects(client)
 
 	err := client.CreateIRule(name, d.Get("irule").(string))
 	if err != nil {
@@ -61,7 +95,8 @@ func resourceBigipLtmIRuleCreate(ctx context.Context, d *schema.ResourceData, me
 
 	d.SetId(name)
 
-	return resourceBigipLtmIRuleRead(ctx, d, meta)
+	diags := resourceBigipLtmIRuleRead(ctx, d, meta)
+	return append(diags, iruleReferenceWarnings(client, d)...)
 }
 
 func resourceBigipLtmIRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -82,6 +117,13 @@ func resourceBigipLtmIRuleRead(ctx context.Context, d *schema.ResourceData, meta
 
 	_ = d.Set("name", irule.FullPath)
 	_ = d.Set("irule", irule.Rule)
+	_ = d.Set("references", flattenIRuleReferences(iruleReferences(irule.Rule)))
+	if _, ok := d.GetOk("strict_validation"); !ok {
+		_ = d.Set("strict_validation", false)
+	}
+	if _, ok := d.GetOk("reference_validation"); !ok {
+		_ = d.Set("reference_validation", "warn")
+	}
 
 	return nil
 }
@@ -90,6 +132,7 @@ func resourceBigipLtmIRuleUpdate(ctx context.Context, d *schema.ResourceData, me
 	client := meta.(*bigip.BigIP)
 
 	name := d.Id()
+	forgetPlannedIRuleObjects(client)
 
 	r := &bigip.IRule{
 		FullPath: name,
@@ -100,7 +143,8 @@ func resourceBigipLtmIRuleUpdate(ctx context.Context, d *schema.ResourceData, me
 	if err != nil {
 		return diag.FromErr(fmt.Errorf("error modifying iRule %s: %v", name, err))
 	}
-	return resourceBigipLtmIRuleRead(ctx, d, meta)
+	diags := resourceBigipLtmIRuleRead(ctx, d, meta)
+	return append(diags, iruleReferenceWarnings(client, d)...)
 }
 
 func resourceBigipLtmIRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -113,3 +157,99 @@ func resourceBigipLtmIRuleDelete(ctx context.Context, d *schema.ResourceData, me
 	d.SetId("")
 	return nil
 }
+
+// iruleStrictWarnings returns the lint warnings failing the plan in strict mode, all but the advisory ones.
+func iruleStrictWarnings(body string) []string {
+	var warnings []string
+	for _, finding := range lintIRule(body) {
+		if finding.severity == diag.Warning && !finding.advisory {
+			warnings = append(warnings, finding.String())
+		}
+	}
+	return warnings
+}
+
+func resourceBigipLtmIRuleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// a templated body is unknown until the values it uses are, e.g. the name of a data group created later
+	if !d.NewValueKnown("irule") {
+		if err := d.SetNewComputed("references"); err != nil {
+			return err
+		}
+		return d.SetNewComputed("reference_warnings")
+	}
+	body := d.Get("irule").(string)
+	if d.Get("strict_validation").(bool) {
+		if warnings := iruleStrictWarnings(body); len(warnings) > 0 {
+			return fmt.Errorf("iRule validation failed in strict mode:\n  %s", strings.Join(warnings, "\n  "))
+		}
+	}
+	references := iruleReferences(body)
+	if d.HasChange("irule") {
+		if err := d.SetNew("references", flattenIRuleReferences(references)); err != nil {
+			return err
+		}
+	}
+	client, ok := meta.(*bigip.BigIP)
+	mode := d.Get("reference_validation").(string)
+	if !ok || mode == "none" || len(references) == 0 {
+		return nil
+	}
+	// unchanged references are looked up again only when the plan destroys objects they could point at
+	if !d.HasChange("irule") && !plansIRuleObjectDestruction(client) {
+		return nil
+	}
+	name := d.Get("name").(string)
+	problems, err := checkIRuleReferences(client, iRulePartition(name), references, true)
+	if err != nil {
+		return fmt.Errorf("error checking references of iRule %s: %v", name, err)
+	}
+	if len(problems) > 0 && mode == "error" {
+		return fmt.Errorf("iRule %s references objects that do not exist: %s", name, iruleReferencesString(problems))
+	}
+	// the warnings are part of the plan, CustomizeDiff cannot return warning diagnostics
+	warnings := iruleReferenceWarningList(problems)
+	if !reflect.DeepEqual(warnings, d.Get("reference_warnings")) {
+		return d.SetNew("reference_warnings", warnings)
+	}
+	return nil
+}
+
+// iruleReferenceWarnings sets reference_warnings to the references of the applied iRule that do not exist, and
+// returns them as a warning.
+func iruleReferenceWarnings(client *bigip.BigIP, d *schema.ResourceData) diag.Diagnostics {
+	if d.Get("reference_validation").(string) != "warn" {
+		_ = d.Set("reference_warnings", []interface{}{})
+		return nil
+	}
+	name := d.Get("name").(string)
+	problems, err := checkIRuleReferences(client, iRulePartition(name), iruleReferences(d.Get("irule").(string)), false)
+	if err != nil {
+		log.Printf("[WARN] Unable to check references of iRule %s: %v", name, err)
+		return nil
+	}
+	_ = d.Set("reference_warnings", iruleReferenceWarningList(problems))
+	if len(problems) == 0 {
+		return nil
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("iRule %s references objects that do not exist", name),
+		Detail:   iruleReferencesString(problems),
+	}}
+}
+
+func iruleReferenceWarningList(problems []iruleReferenceProblem) []interface{} {
+	warnings := make([]interface{}, 0, len(problems))
+	for _, problem := range problems {
+		warnings = append(warnings, problem.String())
+	}
+	return warnings
+}
+
+func iRulePartition(name string) string {
+	parts := strings.Split(name, "/")
+	if len(parts) < 3 {
+		return "Common"
+	}
+	return parts[1]
+}
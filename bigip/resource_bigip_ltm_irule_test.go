@@ -8,6 +8,7 @@ package bigip
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	bigip "github.com/efellowsbg/go-bigip"
@@ -66,6 +67,106 @@ func TestAccBigipLtmIRule_import(t *testing.T) {
 	})
 }
 
+var TEST_IRULE_STRICT_RESOURCE = `
+	resource "bigip_ltm_irule" "test-rule" {
+		name              = "` + TEST_IRULE_NAME + `"
+		strict_validation = true
+		irule             = <<EOF
+when HTTP_REQUEST {
+     if { [matchclass [IP::client_addr] equals blocked_clients] } {
+          drop
+     }
+}
+EOF
+	}`
+
+var TEST_IRULE_UNBALANCED_RESOURCE = `
+	resource "bigip_ltm_irule" "test-rule" {
+		name  = "` + TEST_IRULE_NAME + `"
+		irule = <<EOF
+when HTTP_REQUEST {
+     log local0. "test"
+EOF
+	}`
+
+func TestAccBigipLtmIRule_validation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckIRulesDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config:      TEST_IRULE_UNBALANCED_RESOURCE,
+				ExpectError: regexp.MustCompile("iRule line 1: missing close-brace"),
+			},
+			{
+				Config:      TEST_IRULE_STRICT_RESOURCE,
+				ExpectError: regexp.MustCompile(`line 2: command "matchclass" is deprecated`),
+			},
+		},
+	})
+}
+
+var TEST_IRULE_REFERENCES_RESOURCE = `
+	resource "bigip_ltm_datagroup" "test-dg" {
+		name = "/` + TestPartition + `/test-irule-dg"
+		type = "string"
+		record {
+			name = "/blocked"
+		}
+	}
+	resource "bigip_ltm_irule" "test-rule" {
+		name                 = "` + TEST_IRULE_NAME + `"
+		reference_validation = "error"
+		irule                = <<EOF
+when HTTP_REQUEST {
+     if { [class match [HTTP::uri] starts_with ${bigip_ltm_datagroup.test-dg.name}] } {
+          drop
+     }
+}
+EOF
+	}`
+
+var TEST_IRULE_MISSING_REFERENCE_RESOURCE = `
+	resource "bigip_ltm_irule" "test-rule" {
+		name                 = "` + TEST_IRULE_NAME + `"
+		reference_validation = "error"
+		irule                = <<EOF
+when HTTP_REQUEST {
+     if { [class match [HTTP::uri] starts_with test-irule-missing-dg] } {
+          drop
+     }
+}
+EOF
+	}`
+
+func TestAccBigipLtmIRule_references(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckIRulesDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config:      TEST_IRULE_MISSING_REFERENCE_RESOURCE,
+				ExpectError: regexp.MustCompile("references objects that do not exist: datagroup test-irule-missing-dg"),
+			},
+			{
+				Config: TEST_IRULE_REFERENCES_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckIRuleExists(TEST_IRULE_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_irule.test-rule", "references.#", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_irule.test-rule", "references.0.type", "datagroup"),
+					resource.TestCheckResourceAttr("bigip_ltm_irule.test-rule", "references.0.name", "/"+TestPartition+"/test-irule-dg"),
+				),
+			},
+		},
+	})
+}
+
 func testCheckIRuleExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client := testAccProvider.Meta().(*bigip.BigIP)
@@ -127,6 +127,12 @@ func resourceBigipLtmNode() *schema.Resource {
 					},
 				},
 			},
+			"resolved_addresses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IP addresses the FQDN of the node currently resolves to, taken from the ephemeral nodes created by the system.",
+			},
 		},
 	}
 }
@@ -243,6 +249,14 @@ func resourceBigipLtmNodeRead(ctx context.Context, d *schema.ResourceData, meta
 		fqdn = append(fqdn, fqdnelements)
 		_ = d.Set("fqdn", fqdn)
 	}
+	resolved := []string{}
+	if node.FQDN.Name != "" {
+		resolved, err = fqdnResolvedAddresses(client, node.Partition, node.FQDN.Name)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error retrieving resolved addresses of node (%s): %s", name, err))
+		}
+	}
+	_ = d.Set("resolved_addresses", resolved)
 	return nil
 }
 
@@ -26,6 +26,7 @@ func resourceBigipLtmPool() *schema.Resource {
 		ReadContext:   resourceBigipLtmPoolRead,
 		UpdateContext: resourceBigipLtmPoolUpdate,
 		DeleteContext: resourceBigipLtmPoolDelete,
+		CustomizeDiff: customizeDiffIRuleObject(iruleReferencePool, plannedName),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -12,8 +12,10 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"net/url"
 	"regexp"
 	"strings"
+	"time"
 
 	bigip "github.com/efellowsbg/go-bigip"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -97,10 +99,39 @@ func resourceBigipLtmPoolAttachment() *schema.Resource {
 				ForceNew:    true,
 				Description: "Specifies whether the node should scale to the IP address set returned by DNS.",
 			},
+			"drain": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Drain the pool member before it is removed from the pool, either on destroy or when `pool`/`node` changes. The member is taken out of rotation and removed once its current connections reach zero or `drain_timeout` expires.",
+			},
+			"drain_state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "disabled",
+				ValidateFunc: validation.StringInSlice([]string{"disabled", "forced_offline"}, false),
+				Description:  "State the pool member is put into while draining, value can be `disabled` (or) `forced_offline`",
+			},
+			"drain_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      300,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of seconds to wait for the pool member connections to drain before it is removed. Default: 300",
+			},
+			"ephemeral_members": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Ephemeral pool members the system created for the resolved addresses of an FQDN pool member",
+				Elem:        ephemeralPoolMemberSchema(),
+			},
 		},
 	}
 }
 
+// poolMemberDrainInterval is how often the current connections of a draining pool member are polled.
+var poolMemberDrainInterval = 5 * time.Second
+
 func resourceBigipLtmPoolAttachmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
 	poolName := d.Get("pool").(string)
@@ -334,6 +365,7 @@ func resourceBigipLtmPoolAttachmentRead(ctx context.Context, d *schema.ResourceD
 				_ = d.Set("connection_rate_limit", node.RateLimit)
 				_ = d.Set("dynamic_ratio", node.DynamicRatio)
 				_ = d.Set("monitor", node.Monitor)
+				setPoolAttachmentEphemeralMembers(d, node, nodes.PoolMembers)
 				found = true
 				break
 			}
@@ -349,6 +381,7 @@ func resourceBigipLtmPoolAttachmentRead(ctx context.Context, d *schema.ResourceD
 				_ = d.Set("connection_rate_limit", node.RateLimit)
 				_ = d.Set("dynamic_ratio", node.DynamicRatio)
 				_ = d.Set("monitor", node.Monitor)
+				setPoolAttachmentEphemeralMembers(d, node, nodes.PoolMembers)
 				found = true
 				break
 			}
@@ -368,6 +401,14 @@ func resourceBigipLtmPoolAttachmentDelete(ctx context.Context, d *schema.Resourc
 	poolName := d.Get("pool").(string)
 	nodeName := d.Get("node").(string)
 
+	if d.Get("drain").(bool) {
+		drainTimeout := time.Duration(d.Get("drain_timeout").(int)) * time.Second
+		err := drainPoolMember(ctx, client, poolName, nodeName, d.Get("drain_state").(string), drainTimeout)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("failure draining node %s from pool %s: %s", nodeName, poolName, err))
+		}
+	}
+
 	log.Printf("[INFO] Removing node %s from pool: %s", nodeName, poolName)
 
 	err := client.DeletePoolMember(poolName, nodeName)
@@ -435,6 +476,95 @@ func resourceBigipLtmPoolAttachmentImport(ctx context.Context, d *schema.Resourc
 
 	return []*schema.ResourceData{d}, nil
 }
+
+// drainPoolMember takes the pool member out of rotation and waits until its current
+// connections reach zero or the timeout expires, whichever happens first.
+func drainPoolMember(ctx context.Context, client *bigip.BigIP, poolName, member, drainState string, timeout time.Duration) error {
+	memberPath := poolMemberPath(member)
+	config := &bigip.PoolMember{
+		FullPath: memberPath,
+		Session:  "user-disabled",
+		State:    "user-up",
+	}
+	if drainState == "forced_offline" {
+		config.State = "user-down"
+	}
+	log.Printf("[INFO] Draining pool member %s of pool %s (state: %s, timeout: %s)", member, poolName, drainState, timeout)
+	if err := client.ModifyPoolMember(poolName, config); err != nil {
+		return err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		req := &bigip.APIRequest{
+			Method:      "get",
+			URL:         fmt.Sprintf("ltm/pool/%s/members/%s/stats", strings.ReplaceAll(poolName, "/", "~"), memberPath),
+			ContentType: "application/json",
+		}
+		resp, err := client.APICall(req)
+		if err != nil {
+			return err
+		}
+		conns, err := poolMemberCurrentConnections(resp)
+		if err != nil {
+			return err
+		}
+		log.Printf("[DEBUG] Pool member %s of pool %s has %d current connections", member, poolName, conns)
+		if conns == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			log.Printf("[WARN] Pool member %s of pool %s still has %d connections after %s, removing anyway", member, poolName, conns, timeout)
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(poolMemberDrainInterval):
+		}
+	}
+}
+
+// setPoolAttachmentEphemeralMembers records the ephemeral members resolved from the FQDN of member.
+func setPoolAttachmentEphemeralMembers(d *schema.ResourceData, member bigip.PoolMember, members []bigip.PoolMember) {
+	var ephemeral []bigip.PoolMember
+	if member.FQDN.Name != "" {
+		parts := SplitNodePort(member.Name)
+		ephemeral = filterEphemeralPoolMembers(members, member.FQDN.Name, parts[len(parts)-1])
+	}
+	_ = d.Set("ephemeral_members", flattenEphemeralPoolMembers(ephemeral))
+}
+
+// poolMemberPath converts a pool member name into the form expected in iControl REST URLs.
+func poolMemberPath(member string) string {
+	memberPath := strings.ReplaceAll(member, "/", "~")
+	if strings.Contains(memberPath, "%") {
+		memberPath = url.PathEscape(memberPath)
+	}
+	return memberPath
+}
+
+// poolMemberCurrentConnections returns the server side current connections from a pool member stats response.
+func poolMemberCurrentConnections(data []byte) (int, error) {
+	var stats struct {
+		Entries map[string]struct {
+			NestedStats struct {
+				Entries map[string]struct {
+					Value int `json:"value"`
+				} `json:"entries"`
+			} `json:"nestedStats"`
+		} `json:"entries"`
+	}
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return 0, err
+	}
+	for _, entry := range stats.Entries {
+		if conns, ok := entry.NestedStats.Entries["serverside.curConns"]; ok {
+			return conns.Value, nil
+		}
+	}
+	return 0, fmt.Errorf("serverside.curConns not found in pool member stats")
+}
+
 func SplitNodePort(s string) []string {
 	m := strings.Index(s, ":")
 	n := strings.Index(s, ".")
@@ -7,12 +7,18 @@ If a copy of the MPL was not distributed with this file,You can obtain one at ht
 package bigip
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	bigip "github.com/efellowsbg/go-bigip"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 var poolMember = fmt.Sprintf("%s:443", "10.10.10.10")
@@ -101,6 +107,23 @@ resource "bigip_ltm_pool" "test-pool" {
         reselect_tries = "2"
 }
 `
+var TestPoolResourceDrain = `
+resource "bigip_ltm_pool" "test-pool" {
+        name = "` + TestPoolName + `"
+        monitors = ["/Common/http"]
+        allow_nat = "yes"
+        allow_snat = "yes"
+        description = "Test-Pool-Sample"
+        load_balancing_mode = "round-robin"
+}
+resource "bigip_ltm_pool_attachment" "test-pool_test-node" {
+	pool          = bigip_ltm_pool.test-pool.name
+	node          = "` + poolMember + `"
+	drain         = true
+	drain_state   = "forced_offline"
+	drain_timeout = 30
+}
+`
 var TestPoolResource5 = `
 resource "bigip_ltm_pool" "test-pool" {
         name = "` + TestPoolName + `"
@@ -406,6 +429,94 @@ func TestAccBigipLtmPoolAttachmentTestCases(t *testing.T) {
 	})
 }
 
+func TestAccBigipLtmPoolAttachment_Drain(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPoolsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: TestPoolResourceDrain,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolExists(TestPoolName),
+					testCheckPoolAttachment(TestPoolName, poolMemberFullpath, true),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "drain", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "drain_state", "forced_offline"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "drain_timeout", "30"),
+				),
+			},
+			{
+				Config: TestPoolResource5,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolExists(TestPoolName),
+					testCheckPoolAttachment(TestPoolName, poolMemberFullpath, false),
+				),
+			},
+		},
+	})
+}
+
+func TestPoolMemberCurrentConnections(t *testing.T) {
+	tests := []struct {
+		name        string
+		stats       string
+		expected    int
+		expectError bool
+	}{
+		{
+			name: "Active connections",
+			stats: `{
+				"kind": "tm:ltm:pool:members:membersstats",
+				"entries": {
+					"https://localhost/mgmt/tm/ltm/pool/~Common~test-pool/members/~Common~10.10.10.10:443/~Common~10.10.10.10:443/stats": {
+						"nestedStats": {
+							"entries": {
+								"serverside.curConns": {"value": 12},
+								"status.enabledState": {"description": "disabled"}
+							}
+						}
+					}
+				}
+			}`,
+			expected: 12,
+		},
+		{
+			name:     "Drained",
+			stats:    `{"entries": {"member": {"nestedStats": {"entries": {"serverside.curConns": {"value": 0}}}}}}`,
+			expected: 0,
+		},
+		{
+			name:        "Missing counter",
+			stats:       `{"entries": {}}`,
+			expectError: true,
+		},
+		{
+			name:        "Malformed JSON",
+			stats:       `{"entries": `,
+			expectError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conns, err := poolMemberCurrentConnections([]byte(tt.stats))
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, conns)
+		})
+	}
+}
+
+func TestPoolMemberPath(t *testing.T) {
+	assert.Equal(t, "~Common~10.10.10.10:443", poolMemberPath("/Common/10.10.10.10:443"))
+	assert.Equal(t, "10.10.10.10:443", poolMemberPath("10.10.10.10:443"))
+	assert.Equal(t, "~TEST2~2.3.2.2%2530:8080", poolMemberPath("/TEST2/2.3.2.2%30:8080"))
+}
+
 func testCheckPoolAttachment(poolName string, expected string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client := testAccProvider.Meta().(*bigip.BigIP)
@@ -608,3 +719,69 @@ func testaccbigipltmPoolattachStateChange() string {
 		}`
 	return tfConfig
 }
+
+func TestDrainPoolMember(t *testing.T) {
+	poolMemberDrainInterval = time.Millisecond
+	defer func() { poolMemberDrainInterval = 5 * time.Second }()
+
+	// handleDrain serves a pool member whose current connections go down by one on each poll, from conns.
+	handleDrain := func(conns int) (*bigip.PoolMember, *int) {
+		modified := &bigip.PoolMember{}
+		polls := 0
+		mux.HandleFunc("/mgmt/tm/ltm/pool/", func(w http.ResponseWriter, r *http.Request) {
+			if strings.HasSuffix(r.URL.Path, "/stats") {
+				assert.Equal(t, "GET", r.Method)
+				current := conns - polls
+				if current < 0 {
+					current = 0
+				}
+				polls++
+				_, _ = fmt.Fprintf(w, `{"entries": {"https://localhost/mgmt/tm/ltm/pool/~Common~test-pool/members/~Common~10.10.10.10:443/stats": {
+					"nestedStats": {"entries": {"serverside.curConns": {"value": %d}}}}}}`, current)
+				return
+			}
+			assert.Equal(t, "PATCH", r.Method)
+			assert.Contains(t, r.URL.Path, "~Common~10.10.10.10:443")
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(modified))
+			_, _ = fmt.Fprint(w, `{}`)
+		})
+		return modified, &polls
+	}
+	newClient := func() *bigip.BigIP {
+		return bigip.NewSession(&bigip.Config{
+			Address:       server.URL,
+			ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+		})
+	}
+
+	t.Run("Connections reach zero", func(t *testing.T) {
+		setup()
+		defer teardown()
+		modified, polls := handleDrain(2)
+		err := drainPoolMember(context.Background(), newClient(), "/Common/test-pool", "/Common/10.10.10.10:443", "disabled", time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, "user-disabled", modified.Session)
+		assert.Equal(t, "user-up", modified.State)
+		assert.Equal(t, 3, *polls, "polled until the connections reach zero")
+	})
+
+	t.Run("Timeout expires", func(t *testing.T) {
+		setup()
+		defer teardown()
+		modified, polls := handleDrain(1000000)
+		err := drainPoolMember(context.Background(), newClient(), "/Common/test-pool", "/Common/10.10.10.10:443", "forced_offline", 20*time.Millisecond)
+		assert.NoError(t, err, "the member is removed anyway once drain_timeout expires")
+		assert.Equal(t, "user-down", modified.State)
+		assert.Greater(t, *polls, 1)
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		setup()
+		defer teardown()
+		handleDrain(1000000)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		err := drainPoolMember(ctx, newClient(), "/Common/test-pool", "/Common/10.10.10.10:443", "disabled", time.Minute)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
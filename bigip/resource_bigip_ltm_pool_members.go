@@ -0,0 +1,438 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// poolMembersPollInterval is how often pool member availability is polled during a rolling update.
+var poolMembersPollInterval = 5 * time.Second
+
+func resourceBigipLtmPoolMembers() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipLtmPoolMembersCreate,
+		ReadContext:   resourceBigipLtmPoolMembersRead,
+		UpdateContext: resourceBigipLtmPoolMembersUpdate,
+		DeleteContext: resourceBigipLtmPoolMembersDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"pool": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the pool whose members are managed by this resource",
+				ValidateFunc: validateF5NameWithDirectory,
+			},
+			"member": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Complete set of pool members. Members present on the pool but not listed here are removed",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Full path of the pool member with service port. e.g /Common/1.1.1.1:80 or /Common/my-node:80",
+							ValidateFunc: validateF5NameWithDirectory,
+						},
+						"ratio": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntBetween(1, 65535),
+							Description:  "Specifies the ratio weight to assign to the pool member. Default: 1",
+						},
+						"priority_group": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     0,
+							Description: "Specifies a number representing the priority group for the pool member. Default: 0",
+						},
+						"connection_limit": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     0,
+							Description: "Specifies a maximum established connection limit for the pool member. Default: 0 (no limit)",
+						},
+						"connection_rate_limit": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "disabled",
+							Description: "Specifies the maximum number of connections-per-second allowed for the pool member. Default: disabled",
+						},
+						"dynamic_ratio": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+							Description: "Sets the dynamic ratio number for the pool member. Default: 1",
+						},
+						"monitor": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "default",
+							Description: "Specifies the health monitors that the system uses to monitor this pool member, value can be `none` (or) `default` (or) list of monitors joined with and",
+						},
+						"state": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "enabled",
+							ValidateFunc: validation.StringInSlice([]string{"disabled", "enabled", "forced_offline"}, false),
+							Description:  "Specifies the state the pool member should be in, value can be `enabled` (or) `disabled` (or) `forced_offline`",
+						},
+					},
+				},
+			},
+			"rolling_update": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Apply membership changes in batches, waiting for new and modified members to come up before continuing",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"batch_size": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Maximum number of members added, modified or removed at a time",
+						},
+						"wait_timeout": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      300,
+							ValidateFunc: validation.IntAtLeast(0),
+							Description:  "Number of seconds to wait for the members of a batch to come up. Default: 300",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceBigipLtmPoolMembersCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	poolName := d.Get("pool").(string)
+	log.Printf("[INFO] Creating pool members of pool %s", poolName)
+	d.SetId(poolName)
+	return resourceBigipLtmPoolMembersUpdate(ctx, d, meta)
+}
+
+func resourceBigipLtmPoolMembersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	poolName := d.Id()
+	log.Printf("[INFO] Reading pool members of pool %s", poolName)
+	pool, err := client.GetPool(poolName)
+	if err != nil && strings.Contains(err.Error(), "not found") {
+		log.Printf("[WARN] Pool (%s) not found, removing from state", poolName)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if pool == nil {
+		log.Printf("[WARN] Pool (%s) not found, removing from state", poolName)
+		d.SetId("")
+		return nil
+	}
+	current, err := getManagedPoolMembers(client, poolName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving pool (%s) members: %s", poolName, err))
+	}
+	var members []interface{}
+	for _, member := range current {
+		members = append(members, flattenPoolMember(member))
+	}
+	_ = d.Set("pool", poolName)
+	if err := d.Set("member", members); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting members of pool (%s): %s", poolName, err))
+	}
+	return nil
+}
+
+func resourceBigipLtmPoolMembersUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	poolName := d.Id()
+
+	var desired []bigip.PoolMember
+	for _, m := range d.Get("member").(*schema.Set).List() {
+		desired = append(desired, expandPoolMember(m.(map[string]interface{})))
+	}
+	current, err := getManagedPoolMembers(client, poolName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving pool (%s) members: %s", poolName, err))
+	}
+	for i, member := range current {
+		current[i] = expandPoolMember(flattenPoolMember(member))
+	}
+
+	batchSize := 0
+	waitTimeout := 0
+	if r, ok := d.GetOk("rolling_update"); ok && len(r.([]interface{})) > 0 && r.([]interface{})[0] != nil {
+		rolling := r.([]interface{})[0].(map[string]interface{})
+		batchSize = rolling["batch_size"].(int)
+		waitTimeout = rolling["wait_timeout"].(int)
+	}
+
+	steps := poolMembersUpdateSteps(current, desired, batchSize)
+	for i, step := range steps {
+		log.Printf("[INFO] Updating members of pool %s (step %d/%d): adding %v, modifying %v, removing %v", poolName, i+1, len(steps),
+			poolMemberNames(step.added), poolMemberNames(step.modified), step.removed)
+		if err := applyPoolMembersStep(client, poolName, step); err != nil {
+			return diag.FromErr(err)
+		}
+		if batchSize > 0 && len(step.waitFor) > 0 {
+			if err := waitForPoolMembersUp(ctx, client, poolName, step.waitFor, time.Duration(waitTimeout)*time.Second); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	return resourceBigipLtmPoolMembersRead(ctx, d, meta)
+}
+
+func resourceBigipLtmPoolMembersDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	poolName := d.Id()
+	log.Printf("[INFO] Removing all members of pool %s", poolName)
+	current, err := getManagedPoolMembers(client, poolName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error retrieving pool (%s) members: %s", poolName, err))
+	}
+	step := poolMembersStep{}
+	for _, member := range current {
+		step.removed = append(step.removed, flattenPoolMember(member)["name"].(string))
+	}
+	if err := applyPoolMembersStep(client, poolName, step); err != nil {
+		log.Printf("[ERROR] Unable to remove members of pool (%s) (%v)", poolName, err)
+		return diag.FromErr(err)
+	}
+	d.SetId("")
+	return nil
+}
+
+// applyPoolMembersStep adds, modifies and removes the members of a step one by one, so that the ephemeral
+// members the BIG-IP created for FQDN nodes are left alone.
+func applyPoolMembersStep(client *bigip.BigIP, poolName string, step poolMembersStep) error {
+	for _, member := range step.added {
+		config := member
+		if err := client.AddPoolMember(poolName, &config); err != nil {
+			return fmt.Errorf("error adding member %s to pool (%s): %s", member.Name, poolName, err)
+		}
+	}
+	for _, member := range step.modified {
+		config := member
+		config.FullPath = poolMemberPath(member.Name)
+		if err := client.ModifyPoolMember(poolName, &config); err != nil {
+			return fmt.Errorf("error modifying member %s of pool (%s): %s", member.Name, poolName, err)
+		}
+	}
+	for _, name := range step.removed {
+		// DeletePoolMember escapes route domains itself
+		if err := client.DeletePoolMember(poolName, strings.ReplaceAll(name, "/", "~")); err != nil {
+			return fmt.Errorf("error removing member %s from pool (%s): %s", name, poolName, err)
+		}
+	}
+	return nil
+}
+
+// getManagedPoolMembers returns the pool members, leaving out the ephemeral members created for FQDN nodes.
+func getManagedPoolMembers(client *bigip.BigIP, poolName string) ([]bigip.PoolMember, error) {
+	nodes, err := client.PoolMembers(poolName)
+	if err != nil {
+		return nil, err
+	}
+	var members []bigip.PoolMember
+	if nodes == nil {
+		return members, nil
+	}
+	for _, member := range nodes.PoolMembers {
+		if strings.HasPrefix(member.Name, ephemeralPrefix) {
+			continue
+		}
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func flattenPoolMember(member bigip.PoolMember) map[string]interface{} {
+	name := member.FullPath
+	if name == "" {
+		name = fmt.Sprintf("/%s/%s", member.Partition, member.Name)
+	}
+	state := "enabled"
+	if member.Session == "user-disabled" {
+		state = "disabled"
+		if member.State == "user-down" {
+			state = "forced_offline"
+		}
+	}
+	return map[string]interface{}{
+		"name":                  name,
+		"ratio":                 member.Ratio,
+		"priority_group":        member.PriorityGroup,
+		"connection_limit":      member.ConnectionLimit,
+		"connection_rate_limit": member.RateLimit,
+		"dynamic_ratio":         member.DynamicRatio,
+		"monitor":               member.Monitor,
+		"state":                 state,
+	}
+}
+
+func expandPoolMember(m map[string]interface{}) bigip.PoolMember {
+	member := bigip.PoolMember{
+		Name:            m["name"].(string),
+		Ratio:           m["ratio"].(int),
+		PriorityGroup:   m["priority_group"].(int),
+		ConnectionLimit: m["connection_limit"].(int),
+		RateLimit:       m["connection_rate_limit"].(string),
+		DynamicRatio:    m["dynamic_ratio"].(int),
+		Monitor:         m["monitor"].(string),
+	}
+	switch m["state"].(string) {
+	case "disabled":
+		member.Session = "user-disabled"
+		member.State = "user-up"
+	case "forced_offline":
+		member.Session = "user-disabled"
+		member.State = "user-down"
+	default:
+		member.Session = "user-enabled"
+		member.State = "user-up"
+	}
+	return member
+}
+
+// poolMembersStep is one batch of a pool membership update.
+type poolMembersStep struct {
+	added    []bigip.PoolMember
+	modified []bigip.PoolMember
+	removed  []string
+	waitFor  []string
+}
+
+// poolMembersUpdateSteps computes the batches of member changes that take the pool from current to
+// desired. Additions are applied first, then modifications and finally removals, at most batchSize
+// changes per step. A batchSize of 0 applies everything in one step.
+func poolMembersUpdateSteps(current, desired []bigip.PoolMember, batchSize int) []poolMembersStep {
+	existing := make(map[string]bigip.PoolMember)
+	for _, member := range current {
+		existing[member.Name] = member
+	}
+	wanted := make(map[string]bigip.PoolMember)
+	for _, member := range desired {
+		wanted[member.Name] = member
+	}
+
+	var added, modified, removed []string
+	for name, member := range wanted {
+		old, ok := existing[name]
+		if !ok {
+			added = append(added, name)
+		} else if !reflect.DeepEqual(old, member) {
+			modified = append(modified, name)
+		}
+	}
+	for name := range existing {
+		if _, ok := wanted[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(modified)
+	sort.Strings(removed)
+	isAdded := make(map[string]bool)
+	for _, name := range added {
+		isAdded[name] = true
+	}
+	changes := append(append(added, modified...), removed...)
+
+	if batchSize <= 0 || batchSize > len(changes) {
+		batchSize = len(changes)
+	}
+
+	var steps []poolMembersStep
+	for start := 0; start < len(changes); start += batchSize {
+		end := start + batchSize
+		if end > len(changes) {
+			end = len(changes)
+		}
+		var step poolMembersStep
+		for _, name := range changes[start:end] {
+			member, ok := wanted[name]
+			switch {
+			case !ok:
+				step.removed = append(step.removed, name)
+				continue
+			case isAdded[name]:
+				step.added = append(step.added, member)
+			default:
+				step.modified = append(step.modified, member)
+			}
+			if member.State != "user-down" {
+				step.waitFor = append(step.waitFor, name)
+			}
+		}
+		steps = append(steps, step)
+	}
+	return steps
+}
+
+func poolMemberNames(members []bigip.PoolMember) []string {
+	var names []string
+	for _, member := range members {
+		names = append(names, member.Name)
+	}
+	return names
+}
+
+// waitForPoolMembersUp polls the pool until all the named members report an available
+// monitor state, or the timeout expires.
+func waitForPoolMembersUp(ctx context.Context, client *bigip.BigIP, poolName string, names []string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		nodes, err := client.PoolMembers(poolName)
+		if err != nil {
+			return fmt.Errorf("error retrieving pool (%s) members: %s", poolName, err)
+		}
+		status := make(map[string]string)
+		if nodes != nil {
+			for _, member := range nodes.PoolMembers {
+				status[member.FullPath] = member.State
+			}
+		}
+		var pending []string
+		for _, name := range names {
+			if state := status[name]; state != "up" && state != "unchecked" {
+				pending = append(pending, fmt.Sprintf("%s (%s)", name, state))
+			}
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for members of pool %s to come up: %s", timeout, poolName, strings.Join(pending, ", "))
+		}
+		log.Printf("[DEBUG] Waiting for members of pool %s to come up: %s", poolName, strings.Join(pending, ", "))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(poolMembersPollInterval):
+		}
+	}
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+var testPoolMembersPoolName = fmt.Sprintf("/%s/test-pool-members", TestPartition)
+
+func testAccBigipLtmPoolMembersConfig(members string, rolling string) string {
+	return fmt.Sprintf(`
+resource "bigip_ltm_pool" "test-pool" {
+  name                = "%s"
+  load_balancing_mode = "round-robin"
+}
+resource "bigip_ltm_pool_members" "test-pool-members" {
+  pool = bigip_ltm_pool.test-pool.name
+  %s
+  %s
+}
+`, testPoolMembersPoolName, members, rolling)
+}
+
+func TestAccBigipLtmPoolMembers_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPoolsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipLtmPoolMembersConfig(`
+  member {
+    name = "/Common/10.10.20.1:80"
+  }
+  member {
+    name  = "/Common/10.10.20.2:80"
+    ratio = 2
+  }`, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolExists(testPoolMembersPoolName),
+					testCheckPoolAttachment(testPoolMembersPoolName, "/Common/10.10.20.1:80", true),
+					testCheckPoolAttachment(testPoolMembersPoolName, "/Common/10.10.20.2:80", true),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_members.test-pool-members", "member.#", "2"),
+				),
+			},
+			{
+				Config: testAccBigipLtmPoolMembersConfig(`
+  member {
+    name  = "/Common/10.10.20.2:80"
+    state = "disabled"
+  }
+  member {
+    name = "/Common/10.10.20.3:80"
+  }`, `
+  rolling_update {
+    batch_size   = 1
+    wait_timeout = 60
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					testCheckPoolAttachment(testPoolMembersPoolName, "/Common/10.10.20.1:80", false),
+					testCheckPoolAttachment(testPoolMembersPoolName, "/Common/10.10.20.2:80", true),
+					testCheckPoolAttachment(testPoolMembersPoolName, "/Common/10.10.20.3:80", true),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_members.test-pool-members", "member.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_members.test-pool-members", "rolling_update.0.batch_size", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmPoolMembers_import(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPoolsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBigipLtmPoolMembersConfig(`
+  member {
+    name = "/Common/10.10.20.1:80"
+  }`, ""),
+			},
+			{
+				ResourceName:      "bigip_ltm_pool_members.test-pool-members",
+				ImportStateId:     testPoolMembersPoolName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testPoolMember(name string, state string, ratio int) bigip.PoolMember {
+	return expandPoolMember(map[string]interface{}{
+		"name":                  name,
+		"ratio":                 ratio,
+		"priority_group":        0,
+		"connection_limit":      0,
+		"connection_rate_limit": "disabled",
+		"dynamic_ratio":         1,
+		"monitor":               "default",
+		"state":                 state,
+	})
+}
+
+func TestPoolMembersUpdateSteps(t *testing.T) {
+	current := []bigip.PoolMember{
+		testPoolMember("/Common/10.0.0.1:80", "enabled", 1),
+		testPoolMember("/Common/10.0.0.2:80", "enabled", 1),
+		testPoolMember("/Common/10.0.0.3:80", "enabled", 1),
+	}
+	desired := []bigip.PoolMember{
+		testPoolMember("/Common/10.0.0.2:80", "enabled", 5),
+		testPoolMember("/Common/10.0.0.3:80", "enabled", 1),
+		testPoolMember("/Common/10.0.0.4:80", "enabled", 1),
+		testPoolMember("/Common/10.0.0.5:80", "forced_offline", 1),
+	}
+
+	t.Run("Single step", func(t *testing.T) {
+		steps := poolMembersUpdateSteps(current, desired, 0)
+		assert.Len(t, steps, 1)
+		assert.Equal(t, []string{"/Common/10.0.0.4:80", "/Common/10.0.0.5:80"}, poolMemberNames(steps[0].added))
+		assert.Equal(t, []string{"/Common/10.0.0.2:80"}, poolMemberNames(steps[0].modified))
+		assert.Equal(t, []string{"/Common/10.0.0.1:80"}, steps[0].removed)
+		assert.Equal(t, []string{"/Common/10.0.0.4:80", "/Common/10.0.0.2:80"}, steps[0].waitFor)
+	})
+
+	t.Run("Rolling", func(t *testing.T) {
+		steps := poolMembersUpdateSteps(current, desired, 2)
+		assert.Len(t, steps, 2)
+		// additions first
+		assert.Equal(t, []string{"/Common/10.0.0.4:80", "/Common/10.0.0.5:80"}, poolMemberNames(steps[0].added))
+		assert.Empty(t, steps[0].modified)
+		assert.Empty(t, steps[0].removed)
+		assert.Equal(t, []string{"/Common/10.0.0.4:80"}, steps[0].waitFor)
+		// then modifications and removals
+		assert.Empty(t, steps[1].added)
+		assert.Equal(t, []string{"/Common/10.0.0.2:80"}, poolMemberNames(steps[1].modified))
+		assert.Equal(t, 5, steps[1].modified[0].Ratio)
+		assert.Equal(t, []string{"/Common/10.0.0.1:80"}, steps[1].removed)
+		assert.Equal(t, []string{"/Common/10.0.0.2:80"}, steps[1].waitFor)
+	})
+
+	t.Run("No changes", func(t *testing.T) {
+		assert.Empty(t, poolMembersUpdateSteps(current, current, 1))
+	})
+
+	t.Run("Remove all", func(t *testing.T) {
+		steps := poolMembersUpdateSteps(current, nil, 0)
+		assert.Len(t, steps, 1)
+		assert.Empty(t, steps[0].added)
+		assert.Len(t, steps[0].removed, 3)
+	})
+}
+
+func TestFlattenPoolMemberState(t *testing.T) {
+	member := bigip.PoolMember{FullPath: "/Common/10.0.0.1:80", Session: "monitor-enabled", State: "up"}
+	assert.Equal(t, "enabled", flattenPoolMember(member)["state"])
+	member.Session = "user-disabled"
+	assert.Equal(t, "disabled", flattenPoolMember(member)["state"])
+	member.State = "user-down"
+	assert.Equal(t, "forced_offline", flattenPoolMember(member)["state"])
+}
+
+func TestResourceBigipLtmPoolMembersUpdate(t *testing.T) {
+	setup()
+	defer teardown()
+	var requests []string
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~web", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"name": "web", "partition": "Common", "fullPath": "/Common/web"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~web/members", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			requests = append(requests, r.Method+" members")
+			_, _ = fmt.Fprint(w, `{}`)
+			return
+		}
+		member := `{"name": "%[1]s", "partition": "Common", "fullPath": "/Common/%[1]s", "ratio": %[2]d, "dynamicRatio": 1,
+			"monitor": "default", "rateLimit": "disabled", "session": "monitor-enabled", "state": "up"}`
+		_, _ = fmt.Fprintf(w, `{"items": [%s, %s, %s, %s]}`,
+			fmt.Sprintf(member, "10.0.0.1:80", 1), fmt.Sprintf(member, "10.0.0.2:80", 1),
+			fmt.Sprintf(member, "app.example.com:80", 1), fmt.Sprintf(member, "_auto_192.0.2.10:80", 1))
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~web/members/", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path[len("/mgmt/tm/ltm/pool/~Common~web/members/"):])
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	member := func(name string, ratio int) map[string]interface{} {
+		return map[string]interface{}{"name": name, "ratio": ratio}
+	}
+	d := schema.TestResourceDataRaw(t, resourceBigipLtmPoolMembers().Schema, map[string]interface{}{
+		"pool": "/Common/web",
+		"member": []interface{}{
+			member("/Common/10.0.0.2:80", 3),
+			member("/Common/app.example.com:80", 1),
+			member("/Common/10.0.0.3:80", 1),
+		},
+	})
+	d.SetId("/Common/web")
+	diags := resourceBigipLtmPoolMembersUpdate(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	sort.Strings(requests)
+	// the ephemeral member of the FQDN node is not touched, and the member list is never replaced
+	assert.Equal(t, []string{"DELETE ~Common~10.0.0.1:80", "PATCH ~Common~10.0.0.2:80", "POST members"}, requests)
+}
@@ -21,6 +21,7 @@ func resourceBigipLtmProfileBotDefense() *schema.Resource {
 		ReadContext:   resourceBigipLtmProfileBotDefenseRead,
 		UpdateContext: resourceBigipLtmProfileBotDefenseUpdate,
 		DeleteContext: resourceBigipLtmProfileBotDefenseDelete,
+		CustomizeDiff: customizeDiffBigipCapability("bigip_ltm_profile_bot_defense", bigipCapability{modules: []string{"asm", "dos"}, minVersion: "14.1"}),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -7,14 +7,26 @@ package bigip
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
 	bigip "github.com/efellowsbg/go-bigip"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+const sysLicenseURL = "/mgmt/tm/sys/license"
+
+var licensePollInterval = 10 * time.Second
+
 func resourceBigipSysBigiplicense() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceBigipSysBigiplicenseCreate,
@@ -24,35 +36,115 @@ func resourceBigipSysBigiplicense() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"command": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Default:     "install",
 				Description: "Tmsh command to execute tmsh commands like install",
 			},
 			"registration_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "A unique Key F5 provides for Licensing BIG-IP",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[A-Za-z0-9-]+$`), "must be a registration key, e.g. ABCDE-FGHIJ-KLMNO-PQRST-UVWXYZA"),
+			},
+			"activation": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "online",
+				Description:  "online activates the key with the F5 license server, offline outputs the dossier and installs license_text",
+				ValidateFunc: validation.StringInSlice([]string{"online", "offline"}, false),
+			},
+			"license_text": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Signed license obtained by activating the dossier, for offline activation",
+			},
+			"dossier": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Dossier of the BIG-IP for the registration key, to activate at https://activate.f5.com for offline activation",
+			},
+			"license_end_date": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "A unique Key F5 provides for Licensing BIG-IP",
+				Computed:    true,
+				Description: "Date the license expires, empty for perpetual licenses",
 			},
+			"service_check_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Service check date of the license, which must be later than the build date of software upgrades",
+			},
+			"active_modules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Modules enabled by the license",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+		CustomizeDiff: func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+			if d.Get("activation").(string) == "online" && d.Get("license_text").(string) != "" {
+				return fmt.Errorf("license_text is only used by offline activation")
+			}
+			return nil
 		},
 	}
 }
 
 func resourceBigipSysBigiplicenseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
 
 	command := d.Get("command").(string)
 	registrationKey := d.Get("registration_key").(string)
 	log.Println("[INFO] Creating BigipLicense ")
 
+	if d.Get("activation").(string) == "offline" {
+		log.Printf("[INFO] Generating the dossier of %s", client.Host)
+		result, err := client.RunCommand(&bigip.BigipCommand{
+			Command:     "run",
+			UtilCmdArgs: fmt.Sprintf("-c 'get_dossier -b %s'", registrationKey),
+		})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error generating the dossier: %v", err))
+		}
+		dossier := strings.TrimSpace(result.CommandResult)
+		if dossier == "" || strings.HasPrefix(strings.ToLower(dossier), "error") {
+			return diag.FromErr(fmt.Errorf("error generating the dossier: %s", dossier))
+		}
+		d.SetId(registrationKey)
+		_ = d.Set("dossier", dossier)
+		licenseText := d.Get("license_text").(string)
+		if licenseText == "" {
+			return diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("BIG-IP %s is not licensed yet", client.Host),
+				Detail:   "Activate the dossier at https://activate.f5.com, and set license_text to the license returned.",
+			}}
+		}
+		if err := installBigipLicense(ctx, client, registrationKey, licenseText); err != nil {
+			return diag.FromErr(err)
+		}
+		return resourceBigipSysBigiplicenseRead(ctx, d, meta)
+	}
+
 	err := client.CreateBigiplicense(
 		command,
 		registrationKey,
 	)
-	time.Sleep(300 * time.Second)
 	if err != nil {
 		log.Printf("[ERROR] Unable to Apply License to Bigip  (%v) ", err)
+		return diag.FromErr(fmt.Errorf("error activating the registration key, use offline activation if the BIG-IP cannot reach the F5 license server: %v", err))
+	}
+	if err := waitBigipLicense(ctx, client, registrationKey); err != nil {
 		return diag.FromErr(err)
 	}
 	d.SetId(registrationKey)
@@ -61,11 +153,22 @@ func resourceBigipSysBigiplicenseCreate(ctx context.Context, d *schema.ResourceD
 
 func resourceBigipSysBigiplicenseUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*bigip.BigIP)
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutUpdate))
+	defer cancel()
 
 	registrationKey := d.Id()
 
 	log.Println("[INFO] Updating Bigiplicense " + registrationKey)
 
+	if d.Get("activation").(string) == "offline" {
+		licenseText := d.Get("license_text").(string)
+		if d.HasChange("license_text") && licenseText != "" {
+			if err := installBigipLicense(ctx, client, registrationKey, licenseText); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+		return resourceBigipSysBigiplicenseRead(ctx, d, meta)
+	}
 	r := &bigip.Bigiplicense{
 		Registration_key: registrationKey,
 		Command:          d.Get("command").(string),
@@ -75,6 +178,9 @@ func resourceBigipSysBigiplicenseUpdate(ctx context.Context, d *schema.ResourceD
 		log.Printf("[ERROR] Unable to Apply License to Bigip  (%v) ", err)
 		return diag.FromErr(err)
 	}
+	if err := waitBigipLicense(ctx, client, registrationKey); err != nil {
+		return diag.FromErr(err)
+	}
 	return resourceBigipSysBigiplicenseRead(ctx, d, meta)
 }
 
@@ -85,17 +191,35 @@ func resourceBigipSysBigiplicenseRead(ctx context.Context, d *schema.ResourceDat
 
 	log.Println("[INFO] Reading Bigiplicense " + name)
 
-	licenses, err := client.Bigiplicenses()
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "get",
+		URL:         sysLicenseURL,
+		ContentType: "application/json",
+	})
+	awaitingLicense := d.Get("activation").(string) == "offline" && d.Get("license_text").(string) == ""
 	if err != nil {
+		if awaitingLicense {
+			// an unlicensed BIG-IP can fail to report its license
+			log.Printf("[DEBUG] BIG-IP %s is awaiting its license: %v", client.Host, err)
+			return nil
+		}
 		log.Printf("[ERROR] Unable to Read License from Bigip  (%v) ", err)
 		return diag.FromErr(err)
 	}
-	if licenses == nil {
+	license := make(map[string]interface{})
+	if err := json.Unmarshal(resp, &license); err != nil {
+		return diag.FromErr(fmt.Errorf("error parsing the license of %s: %v", client.Host, err))
+	}
+	status := parseBigipLicense(license)
+	if status.registrationKey != name && !awaitingLicense {
 		log.Printf("[WARN] License (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
-
+	_ = d.Set("registration_key", name)
+	_ = d.Set("license_end_date", status.licenseEndDate)
+	_ = d.Set("service_check_date", status.serviceCheckDate)
+	_ = d.Set("active_modules", status.activeModules)
 	return nil
 }
 
@@ -103,3 +227,61 @@ func resourceBigipSysBigiplicenseDelete(ctx context.Context, d *schema.ResourceD
 	// API does not Exists
 	return nil
 }
+
+type bigipLicenseStatus struct {
+	registrationKey  string
+	licenseEndDate   string
+	serviceCheckDate string
+	activeModules    []string
+}
+
+// parseBigipLicense reads the license stats of /mgmt/tm/sys/license, which are empty while the BIG-IP is unlicensed.
+func parseBigipLicense(license map[string]interface{}) *bigipLicenseStatus {
+	out, _ := json.Marshal(license["entries"])
+	var entries statsMap
+	_ = json.Unmarshal(out, &entries)
+	stats := entries.nested()
+	status := &bigipLicenseStatus{
+		registrationKey:  stats["registrationKey"].Description,
+		licenseEndDate:   stats["licenseEndDate"].Description,
+		serviceCheckDate: stats["serviceCheckDate"].Description,
+	}
+	// active modules are keyed by their link, e.g. .../active_modules/%22Best%20Bundle,%20VE-1G%7CKEY%7C...%22
+	for link := range stats.entry("active_modules").NestedStats.Entries {
+		module, err := url.PathUnescape(path.Base(link))
+		if err != nil {
+			module = path.Base(link)
+		}
+		module = strings.Trim(module, `"`)
+		status.activeModules = append(status.activeModules, strings.SplitN(module, "|", 2)[0])
+	}
+	sort.Strings(status.activeModules)
+	return status
+}
+
+// waitBigipLicense polls the license status until the registration key is active and the BIG-IP is ready.
+func waitBigipLicense(ctx context.Context, client *bigip.BigIP, registrationKey string) error {
+	for {
+		// GetBigipLiceseStatus retries while the BIG-IP restarts its services
+		license, err := client.GetBigipLiceseStatus()
+		if err == nil && parseBigipLicense(license).registrationKey == registrationKey {
+			break
+		}
+		log.Printf("[DEBUG] Waiting for the license of %s to be active", client.Host)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for registration key %s to be active: %v", registrationKey, ctx.Err())
+		case <-time.After(licensePollInterval):
+		}
+	}
+	return waitBigipReady(ctx, client)
+}
+
+// installBigipLicense installs the license signed from the dossier and waits for it to be active.
+func installBigipLicense(ctx context.Context, client *bigip.BigIP, registrationKey, licenseText string) error {
+	log.Printf("[INFO] Installing the license of %s", client.Host)
+	if err := client.InstallLicense(licenseText); err != nil {
+		return fmt.Errorf("error installing license_text: %v", err)
+	}
+	return waitBigipLicense(ctx, client, registrationKey)
+}
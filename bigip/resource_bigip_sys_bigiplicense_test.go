@@ -0,0 +1,189 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipLicense is a GET /mgmt/tm/sys/license response of BIG-IP Virtual Edition 15.1, with the registration key
+// as a format verb. Nested stat collections such as active_modules are keyed by their self link.
+const testBigipLicense = `{
+	"kind": "tm:sys:license:licensestats",
+	"selfLink": "https://localhost/mgmt/tm/sys/license?ver=15.1.8",
+	"entries": {
+		"https://localhost/mgmt/tm/sys/license/0": {
+			"nestedStats": {
+				"entries": {
+					"https://localhost/mgmt/tm/sys/license/0/active_modules": {
+						"nestedStats": {
+							"entries": {
+								"https://localhost/mgmt/tm/sys/license/0/active_modules/%%22Best%%20Bundle,%%20VE-1G%%7CKEY1%%7CRate%%20Shaping%%7CAnti-Virus%%20Checks%%22": {
+									"nestedStats": {
+										"entries": {
+											"featureModules": {"description": "{ \"Rate Shaping\" \"Anti-Virus Checks\" }"},
+											"key": {"description": "KEY1"}
+										}
+									}
+								},
+								"https://localhost/mgmt/tm/sys/license/0/active_modules/%%22APM,%%20Base,%%20VE%%7CKEY2%%22": {
+									"nestedStats": {
+										"entries": {
+											"featureModules": {"description": "{ }"},
+											"key": {"description": "KEY2"}
+										}
+									}
+								}
+							}
+						}
+					},
+					"licensedOnDate": {"description": "2026/03/01"},
+					"licensedVersion": {"description": "15.1.8"},
+					"licenseEndDate": {"description": "2027/03/01"},
+					"licenseStartDate": {"description": "2026/02/28"},
+					"platformId": {"description": "Z100"},
+					"registrationKey": {"description": "%s"},
+					"serviceCheckDate": {"description": "2026/09/15"},
+					"usage": {"description": "Production"}
+				}
+			}
+		}
+	}
+}`
+
+// handleBigipReady serves the mcpd state and readiness of a BIG-IP, ready once the readyAfter-th poll.
+func handleBigipReady(readyAfter int) *int {
+	polls := 0
+	mux.HandleFunc("/mgmt/tm/sys/mcp-state", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"entries": {"https://localhost/mgmt/tm/sys/mcp-state/0": {"nestedStats": {"entries": {"phase": {"description": "running"}}}}}}`)
+	})
+	mux.HandleFunc("/mgmt/tm/sys/ready", func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		ready := "no"
+		if polls >= readyAfter {
+			ready = "yes"
+		}
+		_, _ = fmt.Fprintf(w, `{"entries": {"https://localhost/mgmt/tm/sys/ready/0": {"nestedStats": {"entries": {
+			"configReady": {"description": "yes"}, "licenseReady": {"description": "yes"}, "provisionReady": {"description": "%s"}}}}}}`, ready)
+	})
+	return &polls
+}
+
+func TestParseBigipLicense(t *testing.T) {
+	var license map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(testBigipLicense, "ABCDE-FGHIJ")), &license))
+	status := parseBigipLicense(license)
+	assert.Equal(t, "ABCDE-FGHIJ", status.registrationKey)
+	assert.Equal(t, "2027/03/01", status.licenseEndDate)
+	assert.Equal(t, []string{"APM, Base, VE", "Best Bundle, VE-1G"}, status.activeModules)
+
+	assert.Equal(t, "", parseBigipLicense(map[string]interface{}{"kind": "tm:sys:license:licensestats"}).registrationKey)
+}
+
+func TestResourceBigipSysBigiplicenseCreate(t *testing.T) {
+	licensePollInterval, sysReadyPollInterval = time.Millisecond, time.Millisecond
+	defer func() {
+		licensePollInterval, sysReadyPollInterval = 10*time.Second, 10*time.Second
+	}()
+	setup()
+	defer teardown()
+	readyPolls := handleBigipReady(2)
+	licenseKey := ""
+	licensePolls := 0
+	mux.HandleFunc("/mgmt/tm/sys/license", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			var license bigip.Bigiplicense
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&license))
+			assert.Equal(t, "install", license.Command)
+			licenseKey = license.Registration_key
+			_, _ = fmt.Fprint(w, `{}`)
+			return
+		}
+		// the license shows up on the second poll
+		if licensePolls++; licensePolls == 1 {
+			_, _ = fmt.Fprint(w, `{"kind": "tm:sys:license:licensestats"}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, testBigipLicense, licenseKey)
+	})
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysBigiplicense().Schema, map[string]interface{}{"registration_key": "ABCDE-FGHIJ"})
+	diags := resourceBigipSysBigiplicenseCreate(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "ABCDE-FGHIJ", d.Id())
+	assert.Equal(t, 2, *readyPolls)
+	assert.Equal(t, "2027/03/01", d.Get("license_end_date"))
+	assert.Equal(t, 2, d.Get("active_modules.#"))
+}
+
+func TestResourceBigipSysBigiplicenseOffline(t *testing.T) {
+	licensePollInterval, sysReadyPollInterval = time.Millisecond, time.Millisecond
+	defer func() {
+		licensePollInterval, sysReadyPollInterval = 10*time.Second, 10*time.Second
+	}()
+	setup()
+	defer teardown()
+	handleBigipReady(1)
+	mux.HandleFunc("/mgmt/tm/util/bash", func(w http.ResponseWriter, r *http.Request) {
+		var command bigip.BigipCommand
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&command))
+		assert.Equal(t, "-c 'get_dossier -b ABCDE-FGHIJ'", command.UtilCmdArgs)
+		_, _ = fmt.Fprint(w, `{"command": "run", "commandResult": "8c1f5dbd0f3e\n"}`)
+	})
+	licensed := false
+	mux.HandleFunc("/mgmt/tm/shared/licensing/registration", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		var license map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&license))
+		assert.Equal(t, "signed license", license["licenseText"])
+		licensed = true
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/sys/license", func(w http.ResponseWriter, r *http.Request) {
+		if !licensed {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"code": 400, "message": "Can't load license, may not be operational"}`)
+			return
+		}
+		_, _ = fmt.Fprintf(w, testBigipLicense, "ABCDE-FGHIJ")
+	})
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	raw := map[string]interface{}{"registration_key": "ABCDE-FGHIJ", "activation": "offline"}
+	d := schema.TestResourceDataRaw(t, resourceBigipSysBigiplicense().Schema, raw)
+	diags := resourceBigipSysBigiplicenseCreate(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Contains(t, diags[0].Summary, "is not licensed yet")
+	assert.Equal(t, "8c1f5dbd0f3e", d.Get("dossier"))
+
+	diags = resourceBigipSysBigiplicenseRead(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "ABCDE-FGHIJ", d.Id(), "the resource awaiting its license stays in state")
+
+	raw["license_text"] = "signed license"
+	d = schema.TestResourceDataRaw(t, resourceBigipSysBigiplicense().Schema, raw)
+	diags = resourceBigipSysBigiplicenseCreate(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.True(t, licensed)
+	assert.Equal(t, "2026/09/15", d.Get("service_check_date"))
+}
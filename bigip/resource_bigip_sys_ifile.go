@@ -17,6 +17,7 @@ func resourceBigipSysIfile() *schema.Resource {
 		ReadContext:   resourceBigipSysIfileRead,
 		UpdateContext: resourceBigipSysIfileUpdate,
 		DeleteContext: resourceBigipSysIfileDelete,
+		CustomizeDiff: customizeDiffIRuleObject(iruleReferenceSysIFile, plannedIFileFullPath),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -31,7 +31,7 @@ func resourceBigipSysProvision() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				Description:  "Name of module to provision in BIG-IP.",
-				ValidateFunc: validation.StringInSlice([]string{"afm", "am", "apm", "asm", "avr", "cgnat", "dos", "fps", "gtm", "ilx", "lc", "ltm", "pem", "sslo", "swg", "urldb"}, false),
+				ValidateFunc: validation.StringInSlice([]string{"afm", "am", "apm", "asm", "avr", "cgnat", "dos", "fps", "gtm", "ilx", "lc", "ltm", "pem", "sslo", "swg", "urldb", "vcmp"}, false),
 			},
 			"full_path": {
 				Type:     schema.TypeString,
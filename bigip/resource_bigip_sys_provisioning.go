@@ -0,0 +1,400 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	sysProvisionURL = "/mgmt/tm/sys/provision"
+	transactionURL  = "/mgmt/tm/transaction"
+)
+
+// provisionStartGrace is how long to wait for the BIG-IP to report that it is not ready once it reports the
+// new levels, before waiting for it to be ready.
+var provisionStartGrace = 30 * time.Second
+
+// provisionLevels are ordered by the resources they take, none being the least.
+var provisionLevels = []string{"none", "minimum", "nominal", "custom", "dedicated"}
+
+func resourceBigipSysProvisioning() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipSysProvisioningUpdate,
+		ReadContext:   resourceBigipSysProvisioningRead,
+		UpdateContext: resourceBigipSysProvisioningUpdate,
+		DeleteContext: resourceBigipSysProvisioningDelete,
+		CustomizeDiff: resourceBigipSysProvisioningCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"module": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "Modules to provision. The modules not listed are not provisioned, at level none",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Name of module to provision in BIG-IP.",
+							ValidateFunc: validation.StringInSlice([]string{"afm", "am", "apm", "asm", "avr", "cgnat", "dos", "fps", "gtm", "ilx", "lc", "ltm", "pem", "sslo", "swg", "urldb", "vcmp"}, false),
+						},
+						"level": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "nominal",
+							Description:  "Provisioning level of the module",
+							ValidateFunc: validation.StringInSlice(provisionLevels, false),
+						},
+						"cpu_ratio": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "CPU ratio of the module, only with level custom",
+							ValidateFunc: validation.IntBetween(0, 255),
+						},
+						"disk_ratio": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Disk ratio of the module, only with level custom",
+							ValidateFunc: validation.IntBetween(0, 255),
+						},
+						"memory_ratio": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Memory ratio of the module, only with level custom",
+							ValidateFunc: validation.IntBetween(0, 255),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceBigipSysProvisioningUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	current, err := getProvisioning(client)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading the provisioning of %s: %v", client.Host, err))
+	}
+	changes, err := provisioningChanges(current, provisioningModules(d.Get("module").(*schema.Set)))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if len(changes) > 0 {
+		log.Printf("[INFO] Provisioning %s", provisioningSummary(changes))
+		if err := runProvisioningTransaction(client, changes, false); err != nil {
+			return diag.FromErr(fmt.Errorf("error provisioning %s: %v", provisioningSummary(changes), err))
+		}
+		if err := waitBigipReprovisioned(ctx, client, changes); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	d.SetId("provisioning")
+	return resourceBigipSysProvisioningRead(ctx, d, meta)
+}
+
+func resourceBigipSysProvisioningRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Println("[INFO] Reading Provisioning")
+	current, err := getProvisioning(client)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading the provisioning of %s: %v", client.Host, err))
+	}
+	configured := make(map[string]bool)
+	for _, module := range provisioningModules(d.Get("module").(*schema.Set)) {
+		configured[module.Name] = true
+	}
+	var modules []interface{}
+	for _, module := range current {
+		// modules not provisioned are kept only when they are configured at level none
+		if module.Level == "none" && !configured[module.Name] {
+			continue
+		}
+		modules = append(modules, map[string]interface{}{
+			"name":         module.Name,
+			"level":        module.Level,
+			"cpu_ratio":    module.CpuRatio,
+			"disk_ratio":   module.DiskRatio,
+			"memory_ratio": module.MemoryRatio,
+		})
+	}
+	_ = d.Set("module", modules)
+	return nil
+}
+
+func resourceBigipSysProvisioningDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// provisioning cannot be deleted: the modules stay provisioned
+	log.Printf("[INFO] Removing provisioning from state, the modules stay provisioned")
+	d.SetId("")
+	return nil
+}
+
+// resourceBigipSysProvisioningCustomizeDiff checks the modules, and that the BIG-IP validates the changes
+// against the CPU, memory and disk of the platform.
+func resourceBigipSysProvisioningCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("module") {
+		return nil
+	}
+	modules := provisioningModules(d.Get("module").(*schema.Set))
+	if err := validateProvisioningModules(modules); err != nil {
+		return err
+	}
+	client, ok := meta.(*bigip.BigIP)
+	if !ok || (d.Id() != "" && !d.HasChange("module")) {
+		return nil
+	}
+	current, err := getProvisioning(client)
+	if err != nil {
+		log.Printf("[WARN] Unable to read the provisioning of %s, it is validated on apply: %v", client.Host, err)
+		return nil
+	}
+	changes, err := provisioningChanges(current, modules)
+	if err != nil || len(changes) == 0 {
+		return err
+	}
+	if err := runProvisioningTransaction(client, changes, true); err != nil {
+		return fmt.Errorf("BIG-IP %s cannot provision %s: %v", client.Host, provisioningSummary(changes), err)
+	}
+	return nil
+}
+
+func provisioningModules(set *schema.Set) []bigip.Provision {
+	var modules []bigip.Provision
+	for _, m := range set.List() {
+		module := m.(map[string]interface{})
+		modules = append(modules, bigip.Provision{
+			Name:        module["name"].(string),
+			Level:       module["level"].(string),
+			CpuRatio:    module["cpu_ratio"].(int),
+			DiskRatio:   module["disk_ratio"].(int),
+			MemoryRatio: module["memory_ratio"].(int),
+		})
+	}
+	return modules
+}
+
+// validateProvisioningModules checks the rules of the levels that do not depend on the platform.
+func validateProvisioningModules(modules []bigip.Provision) error {
+	names := make(map[string]bool)
+	var dedicated []string
+	provisioned := 0
+	for _, module := range modules {
+		if names[module.Name] {
+			return fmt.Errorf("module %s is set more than once", module.Name)
+		}
+		names[module.Name] = true
+		if module.Level != "custom" && (module.CpuRatio != 0 || module.DiskRatio != 0 || module.MemoryRatio != 0) {
+			return fmt.Errorf("module %s sets ratios at level %s: ratios are only used at level custom", module.Name, module.Level)
+		}
+		if module.Level == "dedicated" {
+			dedicated = append(dedicated, module.Name)
+		}
+		if module.Level != "none" {
+			provisioned++
+		}
+	}
+	if len(dedicated) > 0 && provisioned > 1 {
+		return fmt.Errorf("module %s is dedicated: all the other modules must be at level none", strings.Join(dedicated, ", "))
+	}
+	return nil
+}
+
+func getProvisioning(client *bigip.BigIP) ([]bigip.Provision, error) {
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "get",
+		URL:         sysProvisionURL,
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var provisions bigip.Provisions
+	if err := json.Unmarshal(resp, &provisions); err != nil {
+		return nil, err
+	}
+	sort.Slice(provisions.Provisions, func(i, j int) bool { return provisions.Provisions[i].Name < provisions.Provisions[j].Name })
+	return provisions.Provisions, nil
+}
+
+// provisioningChanges returns the modules whose provisioning changes, the modules not configured going to
+// level none. Modules taking less resources are changed first, freeing them for the others.
+func provisioningChanges(current, modules []bigip.Provision) ([]bigip.Provision, error) {
+	wanted := make(map[string]bigip.Provision)
+	for _, module := range modules {
+		wanted[module.Name] = module
+	}
+	available := make(map[string]bool)
+	var availableNames []string
+	var changes []bigip.Provision
+	for _, module := range current {
+		available[module.Name] = true
+		availableNames = append(availableNames, module.Name)
+		want, ok := wanted[module.Name]
+		if !ok {
+			want = bigip.Provision{Name: module.Name, Level: "none"}
+		}
+		if want.Level != module.Level || want.CpuRatio != module.CpuRatio || want.DiskRatio != module.DiskRatio || want.MemoryRatio != module.MemoryRatio {
+			changes = append(changes, want)
+		}
+	}
+	for _, module := range modules {
+		if !available[module.Name] {
+			return nil, fmt.Errorf("module %s is not available on this platform, available modules are: %s", module.Name, strings.Join(availableNames, ", "))
+		}
+	}
+	rank := func(level string) int {
+		for i, l := range provisionLevels {
+			if l == level {
+				return i
+			}
+		}
+		return 0
+	}
+	sort.SliceStable(changes, func(i, j int) bool { return rank(changes[i].Level) < rank(changes[j].Level) })
+	return changes, nil
+}
+
+func provisioningSummary(changes []bigip.Provision) string {
+	var summary []string
+	for _, module := range changes {
+		summary = append(summary, fmt.Sprintf("%s=%s", module.Name, module.Level))
+	}
+	return strings.Join(summary, ", ")
+}
+
+// runProvisioningTransaction changes the modules in one transaction, so that the BIG-IP reprovisions once.
+// With validateOnly, the BIG-IP only validates the changes. The transaction is deleted unless it is committed,
+// so that none is left on the BIG-IP, also when validating at plan.
+func runProvisioningTransaction(client *bigip.BigIP, changes []bigip.Provision, validateOnly bool) error {
+	// the transaction is held by a copy of the client, so that other requests are not part of it
+	tx := *client
+	transaction, err := tx.StartTransaction()
+	if err != nil {
+		return err
+	}
+	txURL := fmt.Sprintf("%s/%d", transactionURL, transaction.TransID)
+	committed := false
+	defer func() {
+		if committed {
+			return
+		}
+		tx.Transaction = ""
+		if _, err := tx.APICall(&bigip.APIRequest{Method: "delete", URL: txURL}); err != nil {
+			log.Printf("[DEBUG] Unable to delete transaction %d: %v", transaction.TransID, err)
+		}
+	}()
+	for _, module := range changes {
+		body, err := json.Marshal(map[string]interface{}{
+			"level":       module.Level,
+			"cpuRatio":    module.CpuRatio,
+			"diskRatio":   module.DiskRatio,
+			"memoryRatio": module.MemoryRatio,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = tx.APICall(&bigip.APIRequest{
+			Method:      "patch",
+			URL:         sysProvisionURL + "/" + module.Name,
+			Body:        string(body),
+			ContentType: "application/json",
+		})
+		if err != nil {
+			return fmt.Errorf("error adding module %s to transaction %d: %v", module.Name, transaction.TransID, err)
+		}
+	}
+	if !validateOnly {
+		err = tx.CommitTransaction(transaction.TransID)
+		committed = err == nil
+		return err
+	}
+	tx.Transaction = ""
+	_, err = tx.APICall(&bigip.APIRequest{
+		Method:      "patch",
+		URL:         txURL,
+		Body:        `{"state": "VALIDATING", "validateOnly": true}`,
+		ContentType: "application/json",
+	})
+	return err
+}
+
+// waitBigipReprovisioned waits for the BIG-IP to report the levels of the changed modules, then for it to
+// reprovision them and be ready. Errors are expected while it reprovisions, so they are retried until ctx expires.
+func waitBigipReprovisioned(ctx context.Context, client *bigip.BigIP, changes []bigip.Provision) error {
+	for {
+		current, err := getProvisioning(client)
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else if pending := provisioningPending(current, changes); len(pending) > 0 {
+			reason = fmt.Sprintf("%s not provisioned yet", strings.Join(pending, ", "))
+		} else {
+			break
+		}
+		log.Printf("[DEBUG] Waiting for BIG-IP %s to reprovision: %s", client.Host, reason)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for BIG-IP %s to reprovision, %s: %v", client.Host, reason, ctx.Err())
+		case <-time.After(sysReadyPollInterval):
+		}
+	}
+	// the levels are reported as soon as they are set, the BIG-IP then reports that it is not ready while it
+	// provisions the modules
+	started := time.Now()
+	for time.Since(started) < provisionStartGrace {
+		ready, _, err := bigipReady(client)
+		if !ready || err != nil {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for BIG-IP %s to reprovision: %v", client.Host, ctx.Err())
+		case <-time.After(sysReadyPollInterval):
+		}
+	}
+	return waitBigipReady(ctx, client)
+}
+
+// provisioningPending returns the changes the current provisioning does not report yet.
+func provisioningPending(current, changes []bigip.Provision) []string {
+	reported := make(map[string]bigip.Provision)
+	for _, module := range current {
+		reported[module.Name] = module
+	}
+	var pending []string
+	for _, module := range changes {
+		got := reported[module.Name]
+		if got.Level != module.Level || got.CpuRatio != module.CpuRatio || got.DiskRatio != module.DiskRatio || got.MemoryRatio != module.MemoryRatio {
+			pending = append(pending, fmt.Sprintf("%s=%s", module.Name, module.Level))
+		}
+	}
+	return pending
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// handleBigipProvisioning serves the provisioning of a BIG-IP and its transactions, failing validation with
+// validationError. Committed transactions update levels, and the modules changed are returned in the order of
+// the transaction.
+func handleBigipProvisioning(t *testing.T, levels map[string]string, validationError string) *[]string {
+	return handleBigipProvisioningTransactions(t, levels, validationError, nil)
+}
+
+// handleBigipProvisioningTransactions is handleBigipProvisioning, counting the transactions deleted in deleted.
+func handleBigipProvisioningTransactions(t *testing.T, levels map[string]string, validationError string, deleted *int) *[]string {
+	var changed []string
+	var pending []string
+	pendingLevels := make(map[string]string)
+	mux.HandleFunc("/mgmt/tm/sys/provision", func(w http.ResponseWriter, r *http.Request) {
+		var provisions bigip.Provisions
+		for _, name := range []string{"afm", "asm", "gtm", "ltm"} {
+			provisions.Provisions = append(provisions.Provisions, bigip.Provision{Name: name, Level: levels[name]})
+		}
+		_ = json.NewEncoder(w).Encode(provisions)
+	})
+	mux.HandleFunc("/mgmt/tm/sys/provision/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, "1", r.Header.Get("X-F5-REST-Coordination-Id"))
+		var module bigip.Provision
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&module))
+		if module.Level == "dedicated" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = fmt.Fprint(w, `{"code": 400, "message": "dedicated is not supported"}`)
+			return
+		}
+		pending = append(pending, path.Base(r.URL.Path)+"="+module.Level)
+		pendingLevels[path.Base(r.URL.Path)] = module.Level
+		_, _ = fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/transaction", func(w http.ResponseWriter, r *http.Request) {
+		pending = nil
+		pendingLevels = make(map[string]string)
+		_, _ = fmt.Fprint(w, `{"transId": 1, "state": "STARTED"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/transaction/1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			assert.Equal(t, "", r.Header.Get("X-F5-REST-Coordination-Id"))
+			if deleted != nil {
+				*deleted++
+			}
+			return
+		}
+		var transaction map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&transaction))
+		if transaction["validateOnly"] == true {
+			if validationError != "" {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = fmt.Fprintf(w, `{"code": 400, "message": "%s"}`, validationError)
+				return
+			}
+			_, _ = fmt.Fprint(w, `{"transId": 1, "state": "COMPLETED"}`)
+			return
+		}
+		changed = append(changed, pending...)
+		for name, level := range pendingLevels {
+			levels[name] = level
+		}
+		_, _ = fmt.Fprint(w, `{"transId": 1, "state": "COMPLETED"}`)
+	})
+	return &changed
+}
+
+func TestValidateProvisioningModules(t *testing.T) {
+	assert.NoError(t, validateProvisioningModules([]bigip.Provision{{Name: "ltm", Level: "nominal"}, {Name: "asm", Level: "custom", CpuRatio: 10}}))
+	assert.EqualError(t, validateProvisioningModules([]bigip.Provision{{Name: "ltm", Level: "nominal"}, {Name: "ltm", Level: "minimum"}}),
+		"module ltm is set more than once")
+	assert.EqualError(t, validateProvisioningModules([]bigip.Provision{{Name: "asm", Level: "nominal", MemoryRatio: 10}}),
+		"module asm sets ratios at level nominal: ratios are only used at level custom")
+	assert.EqualError(t, validateProvisioningModules([]bigip.Provision{{Name: "gtm", Level: "dedicated"}, {Name: "ltm", Level: "minimum"}}),
+		"module gtm is dedicated: all the other modules must be at level none")
+	assert.NoError(t, validateProvisioningModules([]bigip.Provision{{Name: "gtm", Level: "dedicated"}, {Name: "ltm", Level: "none"}}))
+}
+
+func TestResourceBigipSysProvisioningUpdate(t *testing.T) {
+	provisionStartGrace, sysReadyPollInterval = time.Millisecond, time.Millisecond
+	defer func() {
+		provisionStartGrace, sysReadyPollInterval = 30*time.Second, 10*time.Second
+	}()
+	setup()
+	defer teardown()
+	levels := map[string]string{"afm": "nominal", "asm": "none", "gtm": "none", "ltm": "nominal"}
+	changed := handleBigipProvisioning(t, levels, "")
+	readyPolls := handleBigipReady(2)
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceBigipSysProvisioning().Schema, map[string]interface{}{
+		"module": []interface{}{
+			map[string]interface{}{"name": "ltm"},
+			map[string]interface{}{"name": "asm", "level": "nominal"},
+			map[string]interface{}{"name": "gtm", "level": "none"},
+		},
+	})
+	diags := resourceBigipSysProvisioningUpdate(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "provisioning", d.Id())
+	// afm is not configured: it is deprovisioned first, freeing its resources for asm, in one transaction
+	assert.Equal(t, []string{"afm=none", "asm=nominal"}, *changed)
+	assert.Equal(t, 2, *readyPolls)
+
+	assert.Equal(t, map[string]string{"afm": "none", "asm": "nominal", "gtm": "none", "ltm": "nominal"}, levels)
+	assert.Equal(t, 3, d.Get("module.#"), "gtm is kept at level none as it is configured")
+
+	*changed = nil
+	diags = resourceBigipSysProvisioningUpdate(context.Background(), d, client)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Empty(t, *changed)
+}
+
+func TestWaitBigipReprovisioned(t *testing.T) {
+	provisionStartGrace, sysReadyPollInterval = time.Millisecond, time.Millisecond
+	defer func() {
+		provisionStartGrace, sysReadyPollInterval = 30*time.Second, 10*time.Second
+	}()
+	setup()
+	defer teardown()
+	// the BIG-IP is still ready when the transaction commits, and reports the new level of asm a few polls later
+	provisionPolls := 0
+	mux.HandleFunc("/mgmt/tm/sys/provision", func(w http.ResponseWriter, r *http.Request) {
+		provisionPolls++
+		level := "none"
+		if provisionPolls > 3 {
+			level = "nominal"
+		}
+		_, _ = fmt.Fprintf(w, `{"items": [{"name": "asm", "level": "%s"}, {"name": "ltm", "level": "nominal"}]}`, level)
+	})
+	readyPolls := handleBigipReady(1)
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	assert.NoError(t, waitBigipReprovisioned(context.Background(), client, []bigip.Provision{{Name: "asm", Level: "nominal"}}))
+	assert.Equal(t, 4, provisionPolls)
+	assert.NotZero(t, *readyPolls)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := waitBigipReprovisioned(ctx, client, []bigip.Provision{{Name: "asm", Level: "dedicated"}})
+	assert.ErrorContains(t, err, "asm=dedicated not provisioned yet")
+}
+
+func TestRunProvisioningTransactionValidateOnly(t *testing.T) {
+	setup()
+	defer teardown()
+	levels := map[string]string{"afm": "none", "asm": "none", "gtm": "none", "ltm": "nominal"}
+	deleted := 0
+	changed := handleBigipProvisioningTransactions(t, levels, "01071008:3: Provisioning failed with error 1 - 'Memory limit exceeded.'", &deleted)
+	client := bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})
+
+	current, err := getProvisioning(client)
+	assert.NoError(t, err)
+	changes, err := provisioningChanges(current, []bigip.Provision{{Name: "ltm", Level: "nominal"}, {Name: "asm", Level: "nominal"}, {Name: "afm", Level: "nominal"}})
+	assert.NoError(t, err)
+	err = runProvisioningTransaction(client, changes, true)
+	assert.ErrorContains(t, err, "Memory limit exceeded")
+	assert.Empty(t, *changed)
+	assert.Equal(t, "", client.Transaction, "the client is not left in the transaction")
+	assert.Equal(t, 1, deleted)
+
+	// the transaction is deleted when a module cannot be added to it
+	err = runProvisioningTransaction(client, []bigip.Provision{{Name: "asm", Level: "dedicated"}}, true)
+	assert.ErrorContains(t, err, "error adding module asm to transaction 1")
+	assert.Equal(t, 2, deleted)
+	err = runProvisioningTransaction(client, []bigip.Provision{{Name: "asm", Level: "dedicated"}}, false)
+	assert.ErrorContains(t, err, "dedicated is not supported")
+	assert.Equal(t, 3, deleted)
+	assert.Empty(t, *changed)
+
+	_, err = provisioningChanges(current, []bigip.Provision{{Name: "pem", Level: "nominal"}})
+	assert.EqualError(t, err, "module pem is not available on this platform, available modules are: afm, asm, gtm, ltm")
+}
@@ -0,0 +1,329 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/efellowsbg/go-bigip/f5teem"
+	"github.com/google/uuid"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+)
+
+const (
+	tsDeclareURL = "/mgmt/shared/telemetry/declare"
+	tsInfoURL    = "/mgmt/shared/telemetry/info"
+)
+
+// tsEmptyDeclaration removes every Telemetry Streaming object when posted.
+const tsEmptyDeclaration = `{"class":"Telemetry"}`
+
+func resourceBigipTs() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigipTsCreate,
+		ReadContext:   resourceBigipTsRead,
+		UpdateContext: resourceBigipTsUpdate,
+		DeleteContext: resourceBigipTsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"ts_json": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Telemetry Streaming declaration as a JSON string",
+				StateFunc: func(v interface{}) string {
+					jsonString, _ := structure.NormalizeJsonString(v)
+					return jsonString
+				},
+				ValidateDiagFunc: validateTsJson,
+			},
+		},
+	}
+}
+
+// validateTsJson checks that ts_json is a JSON Telemetry declaration.
+func validateTsJson(v interface{}, path cty.Path) diag.Diagnostics {
+	var declaration map[string]interface{}
+	if err := json.Unmarshal([]byte(v.(string)), &declaration); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("ts_json contains an invalid JSON: %s", err),
+			AttributePath: path,
+		}}
+	}
+	if declaration["class"] != "Telemetry" {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("ts_json must be a Telemetry declaration, got class %v", declaration["class"]),
+			AttributePath: path,
+		}}
+	}
+	return nil
+}
+
+func resourceBigipTsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Creating TS config in bigip %s", client.Host)
+	if err := postTsDeclaration(client, d.Get("ts_json").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating TS declaration: %v", err))
+	}
+	d.SetId(client.Host)
+	if !client.Teem {
+		id := uuid.New()
+		uniqueID := id.String()
+		assetInfo := f5teem.AssetInfo{
+			Name:    "Terraform-provider-bigip",
+			Version: client.UserAgent,
+			Id:      uniqueID,
+		}
+		teemDevice := f5teem.AnonymousClient(assetInfo, "")
+		f := map[string]interface{}{
+			"Terraform Version": client.UserAgent,
+		}
+		err := teemDevice.Report(f, "bigip_ts", "1")
+		if err != nil {
+			log.Printf("[ERROR]Sending Telemetry data failed:%v", err)
+		}
+	}
+	return resourceBigipTsRead(ctx, d, meta)
+}
+
+func resourceBigipTsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Reading TS config of %s", client.Host)
+	observed, err := getTsDeclaration(client)
+	if err != nil {
+		if strings.Contains(err.Error(), "404") || strings.Contains(err.Error(), "not found") {
+			log.Printf("[WARN] Telemetry Streaming not found on %s, removing from state", client.Host)
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error reading TS declaration: %v", err))
+	}
+	if observed == tsEmptyDeclaration {
+		log.Printf("[WARN] TS declaration of %s is empty, removing from state", client.Host)
+		d.SetId("")
+		return nil
+	}
+	drifted, drift, err := tsDeclarationDrift(d.Get("ts_json").(string), observed)
+	if err != nil {
+		log.Printf("[WARN] Unable to compare the TS declaration of %s with ts_json, drift is not detected: %v", client.Host, err)
+		return nil
+	}
+	if len(drift) > 0 {
+		log.Printf("[INFO] TS declaration of %s drifted from ts_json: %s", client.Host, strings.Join(drift, ", "))
+		_ = d.Set("ts_json", drifted)
+	}
+	return nil
+}
+
+func resourceBigipTsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Updating TS config in bigip %s", client.Host)
+	if err := postTsDeclaration(client, d.Get("ts_json").(string)); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating TS declaration: %v", err))
+	}
+	return resourceBigipTsRead(ctx, d, meta)
+}
+
+func resourceBigipTsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*bigip.BigIP)
+	log.Printf("[INFO] Deleting TS config in bigip %s", client.Host)
+	if err := postTsDeclaration(client, tsEmptyDeclaration); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting TS declaration: %v", err))
+	}
+	d.SetId("")
+	return nil
+}
+
+func postTsDeclaration(client *bigip.BigIP, tsJson string) error {
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "post",
+		URL:         tsDeclareURL,
+		Body:        tsJson,
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return fmt.Errorf("%v %s", err, string(resp))
+	}
+	log.Printf("[DEBUG] TS declare response: %s", string(resp))
+	return nil
+}
+
+// getTsDeclaration returns the declaration TS applied, normalized.
+func getTsDeclaration(client *bigip.BigIP) (string, error) {
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "get",
+		URL:         tsDeclareURL,
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return "", err
+	}
+	var body struct {
+		Declaration map[string]interface{} `json:"declaration"`
+	}
+	if err := json.Unmarshal(resp, &body); err != nil {
+		return "", fmt.Errorf("error parsing TS response: %v", err)
+	}
+	if body.Declaration == nil {
+		return tsEmptyDeclaration, nil
+	}
+	if len(body.Declaration) == 1 && body.Declaration["class"] == "Telemetry" {
+		return tsEmptyDeclaration, nil
+	}
+	out, err := json.Marshal(body.Declaration)
+	return string(out), err
+}
+
+// tsSecretMask replaces the secrets of the declaration TS applied, which are never written to the state.
+const tsSecretMask = "*****"
+
+// tsSecretProperties are the properties of Telemetry Streaming classes holding a secret, either as a plain string
+// or as a Secret object with a cipherText.
+var tsSecretProperties = map[string]bool{
+	"passphrase":      true,
+	"password":        true,
+	"apiKey":          true,
+	"privateKey":      true,
+	"secretAccessKey": true,
+	"sharedKey":       true,
+	"clientSecret":    true,
+}
+
+func isTsSecret(key string, value interface{}) bool {
+	if tsSecretProperties[key] {
+		return true
+	}
+	object, ok := value.(map[string]interface{})
+	_, secret := object["cipherText"]
+	return ok && secret
+}
+
+// maskTsSecrets returns a copy of a declaration value with its secrets replaced by tsSecretMask.
+func maskTsSecrets(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			if isTsSecret(key, item) {
+				masked[key] = tsSecretMask
+				continue
+			}
+			masked[key] = maskTsSecrets(item)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, item := range v {
+			masked[i] = maskTsSecrets(item)
+		}
+		return masked
+	}
+	return value
+}
+
+// tsDeclarationDrift compares the configured declaration with the one TS applied. TS expands the declaration with
+// its defaults and encrypts the secrets, so properties missing from the configuration and secrets are not
+// compared. It returns the JSON pointers of the drifted properties and the configured declaration with the applied
+// values, objects removed from TS being removed from it and objects added to TS added with their secrets masked.
+func tsDeclarationDrift(configured, observed string) (string, []string, error) {
+	var configuredDeclaration, observedDeclaration map[string]interface{}
+	if err := json.Unmarshal([]byte(configured), &configuredDeclaration); err != nil {
+		return "", nil, err
+	}
+	if err := json.Unmarshal([]byte(observed), &observedDeclaration); err != nil {
+		return "", nil, err
+	}
+	var drift []string
+	for name, value := range configuredDeclaration {
+		if object, ok := value.(map[string]interface{}); ok && object["class"] != nil && observedDeclaration[name] == nil {
+			delete(configuredDeclaration, name)
+			drift = append(drift, "/"+name)
+		}
+	}
+	for name, value := range observedDeclaration {
+		object, ok := value.(map[string]interface{})
+		if _, configured := configuredDeclaration[name]; ok && !configured && object["class"] != nil && object["class"] != "Controls" {
+			configuredDeclaration[name] = maskTsSecrets(object)
+			drift = append(drift, "/"+name)
+		}
+	}
+	merged := tsMergeDrift(configuredDeclaration, observedDeclaration, "", &drift)
+	if len(drift) == 0 {
+		return configured, nil, nil
+	}
+	sort.Strings(drift)
+	out, err := json.Marshal(merged)
+	return string(out), drift, err
+}
+
+// tsMergeDrift returns the desired value with the drifted values of actual, secrets excepted, and appends the JSON
+// pointers of the drifted values to drift.
+func tsMergeDrift(desired, actual interface{}, pointer string, drift *[]string) interface{} {
+	desiredObject, ok := desired.(map[string]interface{})
+	actualObject, isObject := actual.(map[string]interface{})
+	if ok && isObject {
+		for key, value := range desiredObject {
+			if isTsSecret(key, value) || isTsSecret(key, actualObject[key]) {
+				continue
+			}
+			desiredObject[key] = tsMergeDrift(value, actualObject[key], pointer+"/"+key, drift)
+		}
+		return desiredObject
+	}
+	if tsValueEqual(maskTsSecrets(desired), maskTsSecrets(actual)) {
+		return desired
+	}
+	*drift = append(*drift, pointer)
+	return maskTsSecrets(actual)
+}
+
+// tsDeclarationApplied reports whether TS applied the configured declaration.
+func tsDeclarationApplied(configured, observed string) bool {
+	_, drift, err := tsDeclarationDrift(configured, observed)
+	return err == nil && len(drift) == 0
+}
+
+func tsValueEqual(desired, actual interface{}) bool {
+	switch desiredValue := desired.(type) {
+	case map[string]interface{}:
+		actualValue, ok := actual.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range desiredValue {
+			if !tsValueEqual(value, actualValue[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		actualValue, ok := actual.([]interface{})
+		if !ok || len(actualValue) != len(desiredValue) {
+			return false
+		}
+		for i := range desiredValue {
+			if !tsValueEqual(desiredValue[i], actualValue[i]) {
+				return false
+			}
+		}
+		return true
+	}
+	return reflect.DeepEqual(desired, actual)
+}
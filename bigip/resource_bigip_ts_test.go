@@ -0,0 +1,129 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTsDeclarationApplied(t *testing.T) {
+	configured := `{"class": "Telemetry", "My_Consumer": {"class": "Telemetry_Consumer", "type": "Splunk", "host": "192.0.2.1", "passphrase": {"cipherText": "apikey"}}}`
+	tests := []struct {
+		name     string
+		observed string
+		applied  bool
+	}{
+		{
+			name:     "defaults and encrypted secrets",
+			observed: `{"class": "Telemetry", "schemaVersion": "1.32.0", "controls": {"class": "Controls", "logLevel": "info"}, "My_Consumer": {"class": "Telemetry_Consumer", "type": "Splunk", "host": "192.0.2.1", "port": 8088, "passphrase": {"cipherText": "$M$Zx$abc", "protected": "SecureVault"}}}`,
+			applied:  true,
+		},
+		{
+			name:     "property changed",
+			observed: `{"class": "Telemetry", "My_Consumer": {"class": "Telemetry_Consumer", "type": "Splunk", "host": "192.0.2.2", "passphrase": {"cipherText": "$M$Zx$abc"}}}`,
+		},
+		{
+			name:     "object removed",
+			observed: `{"class": "Telemetry"}`,
+		},
+		{
+			name:     "object added",
+			observed: `{"class": "Telemetry", "My_Consumer": {"class": "Telemetry_Consumer", "type": "Splunk", "host": "192.0.2.1", "passphrase": {"cipherText": "$M$Zx$abc"}}, "My_Poller": {"class": "Telemetry_System_Poller"}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.applied, tsDeclarationApplied(configured, test.observed))
+		})
+	}
+}
+
+func TestTsDeclarationDrift(t *testing.T) {
+	configured := `{"class": "Telemetry", "My_Consumer": {"class": "Telemetry_Consumer", "type": "Splunk", "host": "192.0.2.1", "passphrase": {"cipherText": "apikey"}}}`
+	observed := `{"class": "Telemetry", "My_Consumer": {"class": "Telemetry_Consumer", "type": "Splunk", "host": "192.0.2.2", "passphrase": {"cipherText": "$M$Zx$abc", "protected": "SecureVault"}}, ` +
+		`"Other_Consumer": {"class": "Telemetry_Consumer", "type": "Generic_HTTP", "host": "192.0.2.3", "headers": [{"name": "Authorization", "value": {"cipherText": "$M$Zx$def"}}], "password": "secret"}}`
+
+	drifted, drift, err := tsDeclarationDrift(configured, observed)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/My_Consumer/host", "/Other_Consumer"}, drift)
+	// the configured secret is kept, and the secrets of the added object are masked
+	assert.JSONEq(t, `{"class": "Telemetry", "My_Consumer": {"class": "Telemetry_Consumer", "type": "Splunk", "host": "192.0.2.2", "passphrase": {"cipherText": "apikey"}}, `+
+		`"Other_Consumer": {"class": "Telemetry_Consumer", "type": "Generic_HTTP", "host": "192.0.2.3", "headers": [{"name": "Authorization", "value": "*****"}], "password": "*****"}}`, drifted)
+	assert.NotContains(t, drifted, "$M$Zx$")
+
+	// once applied, the drifted declaration is not reported again
+	_, drift, err = tsDeclarationDrift(drifted, observed)
+	assert.NoError(t, err)
+	assert.Empty(t, drift)
+
+	assert.True(t, resourceBigipTs().Schema["ts_json"].Sensitive)
+}
+
+func testBigipTsConfig(port int) string {
+	return fmt.Sprintf(`
+resource "bigip_ts" "ts" {
+  ts_json = jsonencode({
+    class = "Telemetry"
+    My_Listener = {
+      class = "Telemetry_Listener"
+      port  = %d
+    }
+    My_Consumer = {
+      class = "Telemetry_Consumer"
+      type  = "default"
+    }
+  })
+}
+`, port)
+}
+
+func TestAccBigipTs_create(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckTsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      `resource "bigip_ts" "ts" { ts_json = jsonencode({ class = "AS3" }) }`,
+				ExpectError: regexp.MustCompile("ts_json must be a Telemetry declaration"),
+			},
+			{
+				Config: testBigipTsConfig(6514),
+				Check:  resource.TestMatchResourceAttr("bigip_ts.ts", "ts_json", regexp.MustCompile(`"port":6514`)),
+			},
+			{
+				Config: testBigipTsConfig(6515),
+				Check:  resource.TestMatchResourceAttr("bigip_ts.ts", "ts_json", regexp.MustCompile(`"port":6515`)),
+			},
+		},
+	})
+}
+
+func testCheckTsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*bigip.BigIP)
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "bigip_ts" {
+			continue
+		}
+		declaration, err := getTsDeclaration(client)
+		if err != nil {
+			return err
+		}
+		if declaration != tsEmptyDeclaration {
+			return fmt.Errorf("TS declaration %s still exists", declaration)
+		}
+	}
+	return nil
+}
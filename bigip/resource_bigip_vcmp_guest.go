@@ -23,6 +23,7 @@ func resourceBigipVcmpGuest() *schema.Resource {
 		UpdateContext: resourceBigipVcmpGuestUpdate,
 		ReadContext:   resourceBigipVcmpGuestRead,
 		DeleteContext: resourceBigipVcmpGuestDelete,
+		CustomizeDiff: customizeDiffBigipCapability("bigip_vcmp_guest", bigipCapability{modules: []string{"vcmp"}, hardware: true}),
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -11,18 +11,17 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"sort"
 	"strings"
-	"sync"
 	"time"
 
+	bigip "github.com/efellowsbg/go-bigip"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
-var p = 0
-var q sync.Mutex
 var unknownVariableValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
 
 func resourceBigiqAs3() *schema.Resource {
@@ -31,21 +30,29 @@ func resourceBigiqAs3() *schema.Resource {
 		ReadContext:   resourceBigiqAs3Read,
 		UpdateContext: resourceBigiqAs3Update,
 		DeleteContext: resourceBigiqAs3Delete,
+		CustomizeDiff: resourceBigiqAs3CustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
 		Schema: map[string]*schema.Schema{
 			"bigiq_address": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The registration key pool to use",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"bigiq_address", "bigiq_user", "bigiq_password"},
+				Description:  "The registration key pool to use",
 			},
 			"bigiq_user": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Sensitive:   true,
-				Description: "The registration key pool to use",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"bigiq_address", "bigiq_user", "bigiq_password"},
+				Sensitive:    true,
+				Description:  "The registration key pool to use",
 			},
 			"bigiq_port": {
 				Type:        schema.TypeString,
@@ -54,10 +61,11 @@ func resourceBigiqAs3() *schema.Resource {
 				Description: "The registration key pool to use",
 			},
 			"bigiq_password": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Sensitive:   true,
-				Description: "The registration key pool to use",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"bigiq_address", "bigiq_user", "bigiq_password"},
+				Sensitive:    true,
+				Description:  "The registration key pool to use",
 			},
 			"bigiq_token_auth": {
 				Type:        schema.TypeBool,
@@ -153,126 +161,307 @@ func resourceBigiqAs3() *schema.Resource {
 				Optional:    true,
 				Description: "Name of Tenant",
 			},
+			"target": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Address of the managed BIG-IP the tenants are deployed to, the target of the declaration",
+			},
+			"task_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the last AS3 task of the BIG-IQ",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the last deployment: deployed or partially deployed",
+			},
 		},
 	}
 }
 
 func resourceBigiqAs3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
-	bigiqRef, err := connectBigIq(d)
+	bigiqRef, err := connectBigIq(d, meta)
 	if err != nil {
 		log.Printf("Connection to BIGIQ Failed with :%v", err)
 		return diag.FromErr(err)
 	}
-	q.Lock()
-	defer q.Unlock()
 	as3Json := d.Get("as3_json").(string)
 	tenantList, _, _ := bigiqRef.GetTenantList(as3Json)
-	targetInfo := bigiqRef.GetTarget(as3Json)
-	_ = d.Set("tenant_list", tenantList)
-	err, successfulTenants := bigiqRef.PostAs3Bigiq(as3Json)
-	if err != nil {
-		if successfulTenants == "" {
-			return diag.FromErr(fmt.Errorf("error creating json  %s: %v", tenantList, err))
-		}
-		_ = d.Set("tenant_list", successfulTenants)
+	targetInfo := bigiqAs3Target(as3Json)
+	if err := checkBigiqTarget(bigiqRef, targetInfo); err != nil {
+		return diag.FromErr(err)
+	}
+	defer lockAs3Tenants(bigiqRef, tenantList)()
+	successfulTenants, diags := deployBigiqAs3(ctx, d, bigiqRef, as3Json, d.Timeout(schema.TimeoutCreate))
+	if successfulTenants == "" {
+		return diags
 	}
 	as3ID := fmt.Sprintf("%s_%s", targetInfo, successfulTenants)
 	d.SetId(as3ID)
-	p++
-	log.Printf("[TRACE] %+v", p)
-	return resourceBigiqAs3Read(ctx, d, meta)
+	return append(diags, resourceBigiqAs3Read(ctx, d, meta)...)
 }
 
 func resourceBigiqAs3Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	time.Sleep(20 * time.Second)
-	bigiqRef, err := connectBigIq(d)
+	bigiqRef, err := connectBigIq(d, meta)
 	if err != nil {
 		log.Printf("Connection to BIGIQ Failed with :%v", err)
 		return diag.FromErr(err)
 	}
-	tenantRef := d.Id()
 	log.Println("[INFO] Reading As3 config")
-	targetRef := strings.Split(tenantRef, "_")[0]
-	name := strings.Split(tenantRef, "_")[1]
-	if name != d.Get("tenant_list").(string) {
-		as3Resp, err := bigiqRef.GetAs3Bigiq(targetRef, d.Get("tenant_list").(string))
-		if err != nil {
-			log.Printf("[ERROR] Unable to retrieve json ")
-			return diag.FromErr(err)
-		}
-		if as3Resp == "" {
-			log.Printf("[WARN] Json (%s) not found, removing from state", d.Id())
-			d.SetId("")
-			return nil
-		}
-		_ = d.Set("as3_json", as3Resp)
-	} else {
-		as3Resp, err := bigiqRef.GetAs3Bigiq(targetRef, name)
-		if err != nil {
-			log.Printf("[ERROR] Unable to retrieve json ")
-			return diag.FromErr(err)
-		}
-		if as3Resp == "" {
-			log.Printf("[WARN] Json (%s) not found, removing from state", d.Id())
-			d.SetId("")
-			return nil
-		}
-		_ = d.Set("as3_json", as3Resp)
-
+	targetRef, name := bigiqAs3ID(d.Id())
+	if tenants := d.Get("tenant_list").(string); tenants != "" {
+		name = tenants
+	}
+	as3Resp, err := bigiqRef.GetAs3Bigiq(targetRef, name)
+	if err != nil {
+		log.Printf("[ERROR] Unable to retrieve json ")
+		return diag.FromErr(err)
 	}
+	if !bigiqAs3HasTenants(as3Resp) {
+		log.Printf("[WARN] Json (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	_ = d.Set("as3_json", as3Resp)
+	_ = d.Set("tenant_list", name)
+	_ = d.Set("target", targetRef)
 	return nil
 }
 
 func resourceBigiqAs3Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	time.Sleep(20 * time.Second)
-	bigiqRef, err := connectBigIq(d)
+	bigiqRef, err := connectBigIq(d, meta)
 	if err != nil {
 		log.Printf("Connection to BIGIQ Failed with :%v", err)
 		return diag.FromErr(err)
 	}
-	as3Json := d.Get("as3_json").(string)
-	q.Lock()
-	defer q.Unlock()
-	log.Printf("[INFO] Updating As3 Config :%s", as3Json)
-	name := d.Get("tenant_list").(string)
+	oldJson, newJson := d.GetChange("as3_json")
+	as3Json := newJson.(string)
+	oldTarget, _ := bigiqAs3ID(d.Id())
+	targetInfo := bigiqAs3Target(as3Json)
+	if err := checkBigiqTarget(bigiqRef, targetInfo); err != nil {
+		return diag.FromErr(err)
+	}
 	tenantList, _, _ := bigiqRef.GetTenantList(as3Json)
-	if tenantList != name {
-		_ = d.Set("tenant_list", tenantList)
+	defer lockAs3Tenants(bigiqRef, tenantList, d.Get("tenant_list").(string))()
+	log.Printf("[INFO] Updating As3 Config :%s", as3Json)
+	successfulTenants, diags := deployBigiqAs3(ctx, d, bigiqRef, as3Json, d.Timeout(schema.TimeoutUpdate))
+	if successfulTenants == "" {
+		return diags
 	}
-	err, successfulTenants := bigiqRef.PostAs3Bigiq(as3Json)
-	if err != nil {
-		if successfulTenants == "" {
-			return diag.FromErr(fmt.Errorf("Error creating json  %s: %v", tenantList, err))
+	d.SetId(fmt.Sprintf("%s_%s", targetInfo, successfulTenants))
+	if oldTarget != "" && oldTarget != targetInfo {
+		// the tenants moved: they are deployed to the new target first, then removed from the old one, except
+		// those that failed to deploy, which keep serving from the old target
+		failedTenants := bigiqAs3FailedTenants(tenantList, successfulTenants)
+		log.Printf("[INFO] Removing tenants moved to %s from %s", targetInfo, oldTarget)
+		if err := removeBigiqAs3Tenants(ctx, bigiqRef, oldJson.(string), oldTarget, failedTenants, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return append(diags, diag.FromErr(fmt.Errorf("tenants %s were deployed to %s, but not removed from %s: %v", successfulTenants, targetInfo, oldTarget, err))...)
+		}
+		if len(failedTenants) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Tenants %s were not moved from %s to %s", strings.Join(failedTenants, ","), oldTarget, targetInfo),
+				Detail:   "The tenants failed to deploy to the new target and are left on the old one, which this resource no longer manages. Remove them from the old target once they are deployed to the new one.",
+			})
 		}
-		_ = d.Set("tenant_list", successfulTenants)
 	}
-	p++
-	return resourceBigiqAs3Read(ctx, d, meta)
+	return append(diags, resourceBigiqAs3Read(ctx, d, meta)...)
 }
 
 func resourceBigiqAs3Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	time.Sleep(20 * time.Second)
-	bigiqRef, err := connectBigIq(d)
+	bigiqRef, err := connectBigIq(d, meta)
 	if err != nil {
 		log.Printf("Connection to BIGIQ Failed with :%v", err)
 		return diag.FromErr(err)
 	}
-	q.Lock()
-	defer q.Unlock()
-	log.Printf("[INFO] Deleting As3 config")
 	name := d.Get("tenant_list").(string)
-	as3Json := d.Get("as3_json").(string)
-	err, failedTenants := bigiqRef.DeleteAs3Bigiq(as3Json, name)
-	if err != nil {
+	defer lockAs3Tenants(bigiqRef, name)()
+	log.Printf("[INFO] Deleting As3 config")
+	targetRef, _ := bigiqAs3ID(d.Id())
+	if err := removeBigiqAs3Tenants(ctx, bigiqRef, d.Get("as3_json").(string), targetRef, nil, d.Timeout(schema.TimeoutDelete)); err != nil {
 		log.Printf("[ERROR] Unable to DeleteContext: %v :", err)
 		return diag.FromErr(err)
 	}
-	if failedTenants != "" {
-		_ = d.Set("tenant_list", name)
-		return resourceBigipAs3Read(ctx, d, meta)
-	}
-	p++
 	d.SetId("")
 	return nil
 }
+
+// resourceBigiqAs3CustomizeDiff shows the target the declaration moves the tenants to.
+func resourceBigiqAs3CustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("as3_json") || !d.NewValueKnown("as3_json") {
+		return nil
+	}
+	if err := d.SetNewComputed("task_id"); err != nil {
+		return err
+	}
+	if target := bigiqAs3Target(d.Get("as3_json").(string)); target != d.Get("target").(string) {
+		return d.SetNew("target", target)
+	}
+	return nil
+}
+
+// bigiqAs3ID returns the target and the tenants of the ID, target_tenants.
+func bigiqAs3ID(id string) (string, string) {
+	parts := strings.SplitN(id, "_", 2)
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// bigiqAs3Target returns the address of the target of a declaration.
+func bigiqAs3Target(as3Json string) string {
+	var as3 struct {
+		Declaration struct {
+			Target struct {
+				Address string `json:"address"`
+			} `json:"target"`
+		} `json:"declaration"`
+	}
+	_ = json.Unmarshal([]byte(as3Json), &as3)
+	return as3.Declaration.Target.Address
+}
+
+// bigiqAs3HasTenants reports whether the declaration read from the BIG-IQ holds a tenant.
+func bigiqAs3HasTenants(as3Json string) bool {
+	var as3 struct {
+		Declaration map[string]interface{} `json:"declaration"`
+	}
+	_ = json.Unmarshal([]byte(as3Json), &as3)
+	for _, value := range as3.Declaration {
+		if tenant, ok := value.(map[string]interface{}); ok && tenant["class"] == "Tenant" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBigiqTarget checks that the target of a declaration is a BIG-IP managed by the BIG-IQ.
+func checkBigiqTarget(client *bigip.BigIP, target string) error {
+	if target == "" {
+		return fmt.Errorf("the declaration has no target address: set declaration.target.address to the managed BIG-IP to deploy to")
+	}
+	devices, err := client.GetManagedDevices()
+	if err != nil {
+		return fmt.Errorf("error reading the devices managed by BIG-IQ: %v", err)
+	}
+	var managed []string
+	for _, device := range devices.DevicesInfo {
+		if device.Address == target || device.ManagementAddress == target || device.Hostname == target {
+			return nil
+		}
+		managed = append(managed, device.Address)
+	}
+	sort.Strings(managed)
+	return fmt.Errorf("target %s is not a BIG-IP managed by BIG-IQ %s, managed devices are: %s", target, client.Host, strings.Join(managed, ", "))
+}
+
+// deployBigiqAs3 posts the declaration through the BIG-IQ and waits for its task, and returns the tenants
+// deployed. A partial success is returned as a warning.
+func deployBigiqAs3(ctx context.Context, d *schema.ResourceData, client *bigip.BigIP, as3Json string, timeout time.Duration) (string, diag.Diagnostics) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	tenantList, _, _ := client.GetTenantList(as3Json)
+	target := bigiqAs3Target(as3Json)
+	log.Printf("[INFO] Deploying tenants %s to %s through BIG-IQ %s", tenantList, target, client.Host)
+	taskID, successfulTenants, err := deployAs3Tenants(ctx, client, as3Json, "", "")
+	_ = d.Set("task_id", taskID)
+	if err != nil && successfulTenants == "" {
+		return "", diag.FromErr(fmt.Errorf("error creating json  %s: %v", tenantList, err))
+	}
+	_ = d.Set("target", target)
+	if err != nil {
+		_ = d.Set("tenant_list", successfulTenants)
+		_ = d.Set("status", "partially deployed")
+		return successfulTenants, diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Only tenants %s of %s were deployed to %s", successfulTenants, tenantList, target),
+			Detail:   err.Error(),
+		}}
+	}
+	_ = d.Set("tenant_list", tenantList)
+	_ = d.Set("status", "deployed")
+	return tenantList, nil
+}
+
+// bigiqAs3FailedTenants returns the tenants of the comma separated list tenants missing from succeeded.
+func bigiqAs3FailedTenants(tenants, succeeded string) []string {
+	deployed := make(map[string]bool)
+	for _, tenant := range strings.Split(succeeded, ",") {
+		deployed[tenant] = true
+	}
+	var failed []string
+	for _, tenant := range strings.Split(tenants, ",") {
+		if tenant != "" && !deployed[tenant] {
+			failed = append(failed, tenant)
+		}
+	}
+	sort.Strings(failed)
+	return failed
+}
+
+// removeBigiqAs3Tenants removes the tenants of a declaration from a target, posting them empty, except the
+// kept tenants.
+func removeBigiqAs3Tenants(ctx context.Context, client *bigip.BigIP, as3Json, target string, kept []string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	body, err := bigiqAs3RemoveDeclaration(as3Json, target, kept)
+	if err != nil {
+		return err
+	}
+	if body == "" {
+		log.Printf("[INFO] No tenants to remove from %s", target)
+		return nil
+	}
+	task, err := submitAs3Task(ctx, client, "post", as3DeclareURL, body)
+	if err != nil {
+		return err
+	}
+	for _, result := range task.Results {
+		if result.Code >= 400 {
+			return fmt.Errorf("tenant Deletion failed with Response: %s", task)
+		}
+	}
+	return nil
+}
+
+// bigiqAs3RemoveDeclaration returns the declaration removing the tenants of as3Json but the kept ones from the
+// target, or an empty string when no tenant is removed.
+func bigiqAs3RemoveDeclaration(as3Json, target string, kept []string) (string, error) {
+	var as3 map[string]interface{}
+	if err := json.Unmarshal([]byte(as3Json), &as3); err != nil {
+		return "", err
+	}
+	declaration, ok := as3["declaration"].(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("the AS3 json has no declaration")
+	}
+	removal := map[string]interface{}{
+		"target": map[string]interface{}{"address": target},
+	}
+	removed := 0
+	for key, value := range declaration {
+		switch key {
+		case "class", "schemaVersion", "id", "label", "remark":
+			if value != nil {
+				removal[key] = value
+			}
+		default:
+			if tenant, ok := value.(map[string]interface{}); ok && tenant["class"] == "Tenant" && !contains(kept, key) {
+				removal[key] = map[string]interface{}{"class": "Tenant"}
+				removed++
+			}
+		}
+	}
+	if removed == 0 {
+		return "", nil
+	}
+	as3["declaration"] = removal
+	out, err := json.Marshal(as3)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
@@ -0,0 +1,178 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+const testBigiqAs3Json = `{"class": "AS3", "action": "deploy", "persist": true, "declaration": {"class": "ADC", "schemaVersion": "3.7.0",
+	"id": "example-declaration-01", "target": {"address": "10.1.1.10"}, "Task1": {"class": "Tenant", "App": {"class": "Application"}}}}`
+
+const testBigiqDevices = `{"items": [{"address": "10.1.1.10", "hostname": "bigip1.example.com", "managementAddress": "192.0.2.10", "state": "ACTIVE",
+	"product": "BIG-IP", "version": "16.1.3", "httpsPort": 443, "uuid": "uuid-1"},
+	{"address": "10.1.1.9", "hostname": "bigip2.example.com", "state": "ACTIVE", "product": "BIG-IP", "version": "17.1.0", "uuid": "uuid-2"}]}`
+
+func TestBigiqAs3RemoveDeclaration(t *testing.T) {
+	removal, err := bigiqAs3RemoveDeclaration(testBigiqAs3Json, "10.1.1.9", nil)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"class": "AS3", "action": "deploy", "persist": true, "declaration": {"class": "ADC", "schemaVersion": "3.7.0",
+		"id": "example-declaration-01", "target": {"address": "10.1.1.9"}, "Task1": {"class": "Tenant"}}}`, removal)
+
+	removal, err = bigiqAs3RemoveDeclaration(testBigiqAs3Json, "10.1.1.9", []string{"Task1"})
+	assert.NoError(t, err)
+	assert.Empty(t, removal)
+
+	_, err = bigiqAs3RemoveDeclaration(`{"class": "AS3"}`, "10.1.1.9", nil)
+	assert.Error(t, err)
+	assert.Equal(t, "10.1.1.10", bigiqAs3Target(testBigiqAs3Json))
+}
+
+// handleBigiqDevices serves the managed devices, and the self IPs connectBigIq validates the connection with.
+func handleBigiqDevices() {
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"items": []}`)
+	})
+	mux.HandleFunc("/mgmt/shared/resolver/device-groups/cm-bigip-allBigIpDevices/devices", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, testBigiqDevices)
+	})
+}
+
+func testBigiqAs3Data(t *testing.T, r *schema.Resource, raw map[string]interface{}) *schema.ResourceData {
+	raw["bigiq_address"] = server.URL
+	raw["bigiq_user"] = "admin"
+	raw["bigiq_password"] = "secret"
+	raw["bigiq_token_auth"] = false
+	return schema.TestResourceDataRaw(t, r.Schema, raw)
+}
+
+func TestResourceBigiqAs3Create(t *testing.T) {
+	as3TaskPollInterval = time.Millisecond
+	defer func() {
+		as3TaskPollInterval = 3 * time.Second
+	}()
+	setup()
+	defer teardown()
+
+	handleBigiqDevices()
+	var posted []string
+	mux.HandleFunc("/mgmt/shared/appsvcs/declare", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "true", r.URL.Query().Get("async"))
+		body, _ := io.ReadAll(r.Body)
+		posted = append(posted, string(body))
+		_, _ = fmt.Fprint(w, `{"id": "task-1", "results": [{"message": "Declaration successfully submitted", "code": 0}]}`)
+	})
+	mux.HandleFunc("/mgmt/shared/appsvcs/task/task-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id": "task-1", "results": [{"message": "success", "tenant": "Task1", "code": 200}]}`)
+	})
+	mux.HandleFunc("/mgmt/shared/appsvcs/declare/Task1", func(w http.ResponseWriter, r *http.Request) {
+		var as3 map[string]interface{}
+		_ = json.Unmarshal([]byte(testBigiqAs3Json), &as3)
+		out, _ := json.Marshal(as3["declaration"])
+		_, _ = w.Write(out)
+	})
+
+	d := testBigiqAs3Data(t, resourceBigiqAs3(), map[string]interface{}{"as3_json": testBigiqAs3Json})
+	diags := resourceBigiqAs3Create(context.Background(), d, nil)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "10.1.1.10_Task1", d.Id())
+	assert.Equal(t, "10.1.1.10", d.Get("target"))
+	assert.Equal(t, "task-1", d.Get("task_id"))
+	assert.Equal(t, "deployed", d.Get("status"))
+	assert.Len(t, posted, 1)
+
+	diags = resourceBigiqAs3Delete(context.Background(), d, nil)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Len(t, posted, 2)
+	assert.Contains(t, posted[1], `"Task1":{"class":"Tenant"}`)
+
+	d = testBigiqAs3Data(t, resourceBigiqAs3(), map[string]interface{}{
+		"as3_json": `{"class": "AS3", "declaration": {"class": "ADC", "target": {"address": "10.1.1.11"}, "Task1": {"class": "Tenant"}}}`,
+	})
+	diags = resourceBigiqAs3Create(context.Background(), d, nil)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "managed devices are: 10.1.1.10, 10.1.1.9")
+	assert.Len(t, posted, 2)
+}
+
+func TestDataSourceBigiqManagedDevicesRead(t *testing.T) {
+	setup()
+	defer teardown()
+	handleBigiqDevices()
+
+	d := testBigiqAs3Data(t, dataSourceBigiqManagedDevices(), map[string]interface{}{})
+	diags := dataSourceBigiqManagedDevicesRead(context.Background(), d, nil)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, 2, d.Get("devices.#"))
+	assert.Equal(t, "10.1.1.10", d.Get("devices.0.address"))
+	assert.Equal(t, "192.0.2.10", d.Get("devices.0.management_address"))
+	assert.Equal(t, 443, d.Get("devices.0.https_port"))
+	assert.Equal(t, "bigip2.example.com", d.Get("devices.1.hostname"))
+}
+
+func TestResourceBigiqAs3UpdateMovedTenants(t *testing.T) {
+	as3TaskPollInterval = time.Millisecond
+	defer func() {
+		as3TaskPollInterval = 3 * time.Second
+	}()
+	setup()
+	defer teardown()
+
+	handleBigiqDevices()
+	var posted []string
+	mux.HandleFunc("/mgmt/shared/appsvcs/declare", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		posted = append(posted, string(body))
+		_, _ = fmt.Fprintf(w, `{"id": "task-%d", "results": [{"message": "Declaration successfully submitted", "code": 0}]}`, len(posted))
+	})
+	// Task2 fails to deploy to the new target
+	mux.HandleFunc("/mgmt/shared/appsvcs/task/task-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id": "task-1", "results": [{"message": "success", "tenant": "Task1", "code": 200},
+			{"message": "declaration failed", "tenant": "Task2", "code": 422}]}`)
+	})
+	mux.HandleFunc("/mgmt/shared/appsvcs/task/task-2", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"id": "task-2", "results": [{"message": "success", "tenant": "Task1", "code": 200}]}`)
+	})
+	mux.HandleFunc("/mgmt/shared/appsvcs/declare/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"class": "ADC", "target": {"address": "10.1.1.9"}, "Task1": {"class": "Tenant"}}`)
+	})
+
+	declaration := `{"class": "AS3", "declaration": {"class": "ADC", "target": {"address": "%s"}, "Task1": {"class": "Tenant"}, "Task2": {"class": "Tenant"}}}`
+	r := resourceBigiqAs3()
+	d := testBigiqAs3Data(t, r, map[string]interface{}{"as3_json": fmt.Sprintf(declaration, "10.1.1.10")})
+	d.SetId("10.1.1.10_Task1,Task2")
+	_ = d.Set("target", "10.1.1.10")
+	_ = d.Set("tenant_list", "Task1,Task2")
+	state := d.State()
+
+	raw := map[string]interface{}{"as3_json": fmt.Sprintf(declaration, "10.1.1.9")}
+	_ = testBigiqAs3Data(t, r, raw)
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), nil)
+	assert.NoError(t, err)
+	state, diags := r.Apply(context.Background(), state, diff, nil)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "10.1.1.9_Task1", state.ID)
+	assert.Len(t, posted, 2)
+	assert.Contains(t, posted[1], `"address":"10.1.1.10"`)
+	assert.Contains(t, posted[1], `"Task1":{"class":"Tenant"}`)
+	assert.NotContains(t, posted[1], "Task2")
+	var warnings []string
+	for _, diag := range diags {
+		warnings = append(warnings, diag.Summary)
+	}
+	assert.Contains(t, warnings, "Tenants Task2 were not moved from 10.1.1.10 to 10.1.1.9")
+}
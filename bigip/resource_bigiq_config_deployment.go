@@ -0,0 +1,116 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigiqConfigDeployment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigiqConfigDeploymentCreate,
+		ReadContext:   resourceBigiqConfigDeploymentRead,
+		DeleteContext: resourceBigiqConfigDeploymentDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"devices": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "Addresses or hostnames of the managed BIG-IPs to deploy to",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"module": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "adc_core",
+				Description:  "Service module whose configuration is deployed",
+				ValidateFunc: validation.StringInSlice([]string{"adc_core", "asm", "firewall", "security_shared", "dns", "access"}, false),
+			},
+			"skip_verify_config": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Skip the verification of the configuration on the BIG-IPs",
+			},
+			"skip_distribution": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Only evaluate the deployment, without changing the BIG-IPs",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that deploy the configuration again when they change",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"task_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the deployment task of the BIG-IQ",
+			},
+		},
+	}
+}
+
+func resourceBigiqConfigDeploymentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bigiqRef, err := bigiqClient(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	devices := listToStringSlice(d.Get("devices").([]interface{}))
+	var deviceReferences []map[string]string
+	for _, device := range devices {
+		selfLink, err := bigiqManagedDevice(bigiqRef, device)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		deviceReferences = append(deviceReferences, map[string]string{"link": selfLink})
+	}
+	module := d.Get("module").(string)
+	log.Printf("[INFO] Deploying the %s configuration of BIG-IQ %s to %s", module, bigiqRef.Host, strings.Join(devices, ", "))
+	task, err := runBigiqTask(ctx, bigiqRef, fmt.Sprintf(bigiqDeployConfiguration, strings.ReplaceAll(module, "_", "-")), map[string]interface{}{
+		"name":             fmt.Sprintf("terraform-deploy-%d", time.Now().Unix()),
+		"skipVerifyConfig": d.Get("skip_verify_config").(bool),
+		"skipDistribution": d.Get("skip_distribution").(bool),
+		"deviceReferences": deviceReferences,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deploying the configuration to %s: %v", strings.Join(devices, ", "), err))
+	}
+	d.SetId(task.ID)
+	_ = d.Set("task_id", task.ID)
+	return resourceBigiqConfigDeploymentRead(ctx, d, meta)
+}
+
+func resourceBigiqConfigDeploymentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// a deployment is a past event: there is nothing on the BIG-IQ to compare the state with
+	return nil
+}
+
+func resourceBigiqConfigDeploymentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[INFO] Removing deployment %s from state, the configuration stays on the BIG-IPs", d.Id())
+	d.SetId("")
+	return nil
+}
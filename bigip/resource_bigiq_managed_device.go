@@ -0,0 +1,241 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBigiqManagedDevice() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBigiqManagedDeviceCreate,
+		ReadContext:   resourceBigiqManagedDeviceRead,
+		UpdateContext: resourceBigiqManagedDeviceRead,
+		DeleteContext: resourceBigiqManagedDeviceDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Management address of the BIG-IP to discover",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      443,
+				Description:  "Management port of the BIG-IP",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"username": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Username of the BIG-IP, used to establish trust with the BIG-IQ",
+			},
+			"password": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Password of the BIG-IP, used to establish trust with the BIG-IQ",
+			},
+			"modules": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Service modules of the BIG-IP to discover and import, adc_core by default",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"adc_core", "asm", "fps", "security_shared", "dns", "networksecurity", "sslo", "access"}, false),
+				},
+			},
+			"conflict_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "USE_BIGIQ",
+				Description:  "How the import resolves objects that differ between the BIG-IP and the BIG-IQ",
+				ValidateFunc: validation.StringInSlice([]string{"NONE", "USE_BIGIQ", "USE_BIGIP", "KEEP_VERSION"}, false),
+			},
+			"machine_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Machine ID of the BIG-IP",
+			},
+			"uuid": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "UUID of the BIG-IP on the BIG-IQ",
+			},
+			"hostname": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Hostname of the BIG-IP",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Software version of the BIG-IP",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the BIG-IP on the BIG-IQ",
+			},
+		},
+	}
+}
+
+func resourceBigiqManagedDeviceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bigiqRef, err := bigiqClient(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	address := d.Get("address").(string)
+
+	log.Printf("[INFO] Establishing trust between BIG-IQ %s and %s", bigiqRef.Host, address)
+	trust, err := runBigiqTask(ctx, bigiqRef, bigiqDeviceTrustURL, map[string]interface{}{
+		"address":      address,
+		"port":         d.Get("port").(int),
+		"userName":     d.Get("username").(string),
+		"password":     d.Get("password").(string),
+		"clusterName":  "",
+		"useBigiqSync": false,
+		"name":         "trust_" + address,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error establishing trust with %s: %v", address, err))
+	}
+	if trust.MachineID == "" {
+		return diag.FromErr(fmt.Errorf("the device trust task of %s returned no machine ID", address))
+	}
+	// the device is managed from here on, so it is kept in state should discovery or import fail
+	d.SetId(address)
+	_ = d.Set("machine_id", trust.MachineID)
+
+	modules := bigiqDeviceModules(d)
+	log.Printf("[INFO] Discovering the modules %v of %s", d.Get("modules"), address)
+	_, err = runBigiqTask(ctx, bigiqRef, bigiqDeviceDiscoveryURL, map[string]interface{}{
+		"deviceReference": bigiqMachineReference(trust.MachineID),
+		"moduleList":      modules,
+		"status":          "STARTED",
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error discovering %s: %v", address, err))
+	}
+
+	log.Printf("[INFO] Importing the configuration of %s", address)
+	policy := d.Get("conflict_policy").(string)
+	_, err = runBigiqTask(ctx, bigiqRef, bigiqDeviceImportURL, map[string]interface{}{
+		"name":                    "import_" + address,
+		"deviceReference":         bigiqMachineReference(trust.MachineID),
+		"moduleList":              modules,
+		"conflictPolicy":          policy,
+		"deviceConflictPolicy":    policy,
+		"versionedConflictPolicy": policy,
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error importing the configuration of %s: %v", address, err))
+	}
+	return resourceBigiqManagedDeviceRead(ctx, d, meta)
+}
+
+func resourceBigiqManagedDeviceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bigiqRef, err := bigiqClient(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	log.Printf("[INFO] Reading managed device %s", d.Id())
+	devices, err := bigiqRef.GetManagedDevices()
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading the devices managed by BIG-IQ: %v", err))
+	}
+	for _, device := range devices.DevicesInfo {
+		if device.Address != d.Id() && device.ManagementAddress != d.Id() {
+			continue
+		}
+		_ = d.Set("address", d.Id())
+		_ = d.Set("machine_id", device.MachineID)
+		_ = d.Set("uuid", device.UUID)
+		_ = d.Set("hostname", device.Hostname)
+		_ = d.Set("version", device.Version)
+		_ = d.Set("state", device.State)
+		return nil
+	}
+	log.Printf("[WARN] Device (%s) is not managed by BIG-IQ, removing from state", d.Id())
+	d.SetId("")
+	return nil
+}
+
+func resourceBigiqManagedDeviceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bigiqRef, err := bigiqClient(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutDelete))
+	defer cancel()
+	machineID := d.Get("machine_id").(string)
+	log.Printf("[INFO] Removing %s from BIG-IQ %s", d.Id(), bigiqRef.Host)
+	_, err = runBigiqTask(ctx, bigiqRef, bigiqRemoveAuthorityURL, map[string]interface{}{
+		"deviceReference": bigiqMachineReference(machineID),
+		"moduleList":      bigiqDeviceModules(d),
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error removing the management authority of %s: %v", d.Id(), err))
+	}
+	_, err = runBigiqTask(ctx, bigiqRef, bigiqRemoveTrustURL, map[string]interface{}{
+		"deviceReference": bigiqMachineReference(machineID),
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error removing the trust of %s: %v", d.Id(), err))
+	}
+	d.SetId("")
+	return nil
+}
+
+// bigiqDeviceModules returns the moduleList of the device tasks.
+func bigiqDeviceModules(d *schema.ResourceData) []map[string]string {
+	modules := listToStringSlice(d.Get("modules").([]interface{}))
+	if len(modules) == 0 {
+		modules = []string{"adc_core"}
+	}
+	var moduleList []map[string]string
+	for _, module := range modules {
+		moduleList = append(moduleList, map[string]string{"module": module})
+	}
+	return moduleList
+}
+
+// bigiqManagedDevice returns the device managed by the BIG-IQ with the address, management address or hostname.
+func bigiqManagedDevice(client *bigip.BigIP, address string) (string, error) {
+	devices, err := client.GetManagedDevices()
+	if err != nil {
+		return "", fmt.Errorf("error reading the devices managed by BIG-IQ: %v", err)
+	}
+	for _, device := range devices.DevicesInfo {
+		if device.Address == address || device.ManagementAddress == address || device.Hostname == address {
+			return device.SelfLink, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not a BIG-IP managed by BIG-IQ %s", address, client.Host)
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigiqMeta returns the meta of a provider whose bigiq block connects to the test server.
+func testBigiqMeta() interface{} {
+	meta := bigip.NewSession(&bigip.Config{Address: "bigip.example.com"})
+	setBigiqSession(meta, &bigiqSession{client: bigip.NewSession(&bigip.Config{
+		Address:       server.URL,
+		ConfigOptions: &bigip.ConfigOptions{APICallTimeout: 10 * time.Second, APICallRetries: 1},
+	})})
+	return meta
+}
+
+// handleBigiqTask serves a task that finishes on its first poll, recording the posted bodies.
+func handleBigiqTask(t *testing.T, url string, result string, posted *[]map[string]interface{}) {
+	mux.HandleFunc(url, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		*posted = append(*posted, body)
+		_, _ = fmt.Fprint(w, `{"id": "task-1", "status": "STARTED"}`)
+	})
+	mux.HandleFunc(url+"/task-1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, result)
+	})
+}
+
+func TestResourceBigiqManagedDeviceCreate(t *testing.T) {
+	bigiqTaskPollInterval = time.Millisecond
+	defer func() {
+		bigiqTaskPollInterval = 5 * time.Second
+	}()
+	setup()
+	defer teardown()
+	handleBigiqDevices()
+	var trusts, discoveries, imports, removals []map[string]interface{}
+	handleBigiqTask(t, bigiqDeviceTrustURL, `{"id": "task-1", "status": "FINISHED", "machineId": "machine-1"}`, &trusts)
+	handleBigiqTask(t, bigiqDeviceDiscoveryURL, `{"id": "task-1", "status": "FINISHED"}`, &discoveries)
+	handleBigiqTask(t, bigiqDeviceImportURL, `{"id": "task-1", "status": "FINISHED"}`, &imports)
+	handleBigiqTask(t, bigiqRemoveAuthorityURL, `{"id": "task-1", "status": "FAILED", "currentStep": "REMOVE", "errorMessage": "device is busy"}`, &removals)
+	meta := testBigiqMeta()
+
+	d := schema.TestResourceDataRaw(t, resourceBigiqManagedDevice().Schema, map[string]interface{}{
+		"address":  "10.1.1.10",
+		"username": "admin",
+		"password": "secret",
+		"modules":  []interface{}{"adc_core", "asm"},
+	})
+	diags := resourceBigiqManagedDeviceCreate(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "10.1.1.10", d.Id())
+	assert.Equal(t, "uuid-1", d.Get("uuid"))
+	assert.Equal(t, "bigip1.example.com", d.Get("hostname"))
+	assert.Equal(t, "admin", trusts[0]["userName"])
+	assert.Equal(t, map[string]interface{}{"link": "https://localhost/mgmt/cm/system/machineid-resolver/machine-1"}, discoveries[0]["deviceReference"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"module": "adc_core"}, map[string]interface{}{"module": "asm"}}, imports[0]["moduleList"])
+	assert.Equal(t, "USE_BIGIQ", imports[0]["conflictPolicy"])
+
+	diags = resourceBigiqManagedDeviceDelete(context.Background(), d, meta)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "FAILED at step REMOVE: device is busy")
+	assert.Len(t, removals, 1)
+
+	d = schema.TestResourceDataRaw(t, resourceBigiqManagedDevice().Schema, map[string]interface{}{})
+	d.SetId("10.1.1.99")
+	diags = resourceBigiqManagedDeviceRead(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "", d.Id())
+}
+
+func TestResourceBigiqConfigDeploymentCreate(t *testing.T) {
+	bigiqTaskPollInterval = time.Millisecond
+	defer func() {
+		bigiqTaskPollInterval = 5 * time.Second
+	}()
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/shared/resolver/device-groups/cm-bigip-allBigIpDevices/devices", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprint(w, `{"items": [{"address": "10.1.1.10", "hostname": "bigip1.example.com",
+			"selfLink": "https://localhost/mgmt/shared/resolver/device-groups/cm-bigip-allBigIpDevices/devices/uuid-1"}]}`)
+	})
+	var deployments []map[string]interface{}
+	handleBigiqTask(t, "/mgmt/cm/adc-core/tasks/deploy-configuration", `{"id": "task-1", "status": "FINISHED"}`, &deployments)
+	meta := testBigiqMeta()
+
+	d := schema.TestResourceDataRaw(t, resourceBigiqConfigDeployment().Schema, map[string]interface{}{
+		"devices": []interface{}{"bigip1.example.com"},
+	})
+	diags := resourceBigiqConfigDeploymentCreate(context.Background(), d, meta)
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, "task-1", d.Id())
+	assert.Equal(t, []interface{}{map[string]interface{}{"link": "https://localhost/mgmt/shared/resolver/device-groups/cm-bigip-allBigIpDevices/devices/uuid-1"}},
+		deployments[0]["deviceReferences"])
+
+	d = schema.TestResourceDataRaw(t, resourceBigiqConfigDeployment().Schema, map[string]interface{}{
+		"devices": []interface{}{"10.1.1.11"},
+	})
+	diags = resourceBigiqConfigDeploymentCreate(context.Background(), d, meta)
+	assert.True(t, diags.HasError())
+	assert.Contains(t, diags[0].Summary, "10.1.1.11 is not a BIG-IP managed by BIG-IQ")
+	assert.Len(t, deployments, 1)
+}
@@ -30,15 +30,17 @@ func resourceBigiqLicenseManage() *schema.Resource {
 		},
 		Schema: map[string]*schema.Schema{
 			"bigiq_address": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The registration key pool to use",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"bigiq_address", "bigiq_user", "bigiq_password"},
+				Description:  "The registration key pool to use",
 			},
 			"bigiq_user": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Sensitive:   true,
-				Description: "The registration key pool to use",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"bigiq_address", "bigiq_user", "bigiq_password"},
+				Sensitive:    true,
+				Description:  "The registration key pool to use",
 			},
 			"bigiq_port": {
 				Type:        schema.TypeString,
@@ -47,10 +49,11 @@ func resourceBigiqLicenseManage() *schema.Resource {
 				Description: "The registration key pool to use",
 			},
 			"bigiq_password": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Sensitive:   true,
-				Description: "The registration key pool to use",
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"bigiq_address", "bigiq_user", "bigiq_password"},
+				Sensitive:    true,
+				Description:  "The registration key pool to use",
 			},
 			"bigiq_token_auth": {
 				Type:        schema.TypeBool,
@@ -125,7 +128,7 @@ func resourceBigiqLicenseManage() *schema.Resource {
 func resourceBigiqLicenseManageCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	bigipRef := meta.(*bigip.BigIP)
 	log.Printf("[INFO] Start License assignment for :%+v", bigipRef.Host)
-	bigiqRef, err := connectBigIq(d)
+	bigiqRef, err := connectBigIq(d, meta)
 	if err != nil {
 		log.Printf("Connection to BIGIQ Failed with :%v", err)
 		return diag.FromErr(err)
@@ -247,7 +250,7 @@ func resourceBigiqLicenseManageCreate(ctx context.Context, d *schema.ResourceDat
 func resourceBigiqLicenseManageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	bigipRef := meta.(*bigip.BigIP)
 	log.Printf("[INFO] Reading License assignment for :%+v", bigipRef.Host)
-	bigiqRef, err := connectBigIq(d)
+	bigiqRef, err := connectBigIq(d, meta)
 	if err != nil {
 		log.Printf("Connection to BIGIQ Failed with :%v", err)
 		return diag.FromErr(err)
@@ -310,7 +313,7 @@ func resourceBigiqLicenseManageRead(ctx context.Context, d *schema.ResourceData,
 func resourceBigiqLicenseManageUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	bigipRef := meta.(*bigip.BigIP)
 	log.Printf("[INFO] Updating License assignment for :%+v", bigipRef.Host)
-	bigiqRef, err := connectBigIq(d)
+	bigiqRef, err := connectBigIq(d, meta)
 	if err != nil {
 		log.Printf("Connection to BIGIQ Failed with :%v", err)
 		return diag.FromErr(err)
@@ -415,7 +418,7 @@ func resourceBigiqLicenseManageUpdate(ctx context.Context, d *schema.ResourceDat
 func resourceBigiqLicenseManageDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	bigipRef := meta.(*bigip.BigIP)
 	log.Printf("Revoke License assignment for :%+v", bigipRef.Host)
-	bigiqRef, err := connectBigIq(d)
+	bigiqRef, err := connectBigIq(d, meta)
 	if err != nil {
 		log.Printf("Connection to BIGIQ Failed with :%v", err)
 		return diag.FromErr(err)
@@ -529,7 +532,15 @@ func waitLicenseRevoke(bigipRef *bigip.BigIP) (map[string]interface{}, error) {
 	return bigipLicense, err
 }
 
-func connectBigIq(d *schema.ResourceData) (*bigip.BigIP, error) {
+// connectBigIq returns a client of the BIG-IQ set by the bigiq_* arguments of the resource, or else of the
+// shared session of the bigiq block of the provider.
+func connectBigIq(d *schema.ResourceData, meta interface{}) (*bigip.BigIP, error) {
+	if _, ok := d.GetOk("bigiq_address"); !ok {
+		if session := providerBigiqSession(meta); session != nil {
+			return session.Client()
+		}
+		return nil, fmt.Errorf("BIG-IQ is not configured: set the bigiq block of the provider, or bigiq_address, bigiq_user and bigiq_password")
+	}
 	bigiqConfig := bigip.Config{
 		Address:           d.Get("bigiq_address").(string),
 		Port:              d.Get("bigiq_port").(string),
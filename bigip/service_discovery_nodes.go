@@ -0,0 +1,82 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// serviceDiscoveryNode is a node of an event service discovery task, as set by
+// bigip_event_service_discovery.
+type serviceDiscoveryNode struct {
+	ID   string `json:"id" yaml:"id"`
+	IP   string `json:"ip" yaml:"ip"`
+	Port int    `json:"port" yaml:"port"`
+}
+
+// serviceDiscoveryNodesSchema is the node list discovery data sources emit, with the node structure of
+// bigip_event_service_discovery.
+func serviceDiscoveryNodesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Discovered nodes, sorted by id",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the node",
+				},
+				"ip": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "IP address of the node",
+				},
+				"port": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Port of the node",
+				},
+			},
+		},
+	}
+}
+
+// validateServiceDiscoveryNodes checks the addresses and ports of nodes, and that their ids are unique.
+func validateServiceDiscoveryNodes(nodes []serviceDiscoveryNode) error {
+	ids := make(map[string]bool)
+	for _, node := range nodes {
+		if net.ParseIP(node.IP) == nil {
+			return fmt.Errorf("node %s has an invalid IP address %q", node.ID, node.IP)
+		}
+		if node.Port < 0 || node.Port > 65535 {
+			return fmt.Errorf("node %s has an invalid port %d", node.ID, node.Port)
+		}
+		if ids[node.ID] {
+			return fmt.Errorf("duplicate node id %s", node.ID)
+		}
+		ids[node.ID] = true
+	}
+	return nil
+}
+
+func flattenServiceDiscoveryNodes(nodes []serviceDiscoveryNode) []interface{} {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+	list := make([]interface{}, 0, len(nodes))
+	for _, node := range nodes {
+		list = append(list, map[string]interface{}{
+			"id":   node.ID,
+			"ip":   node.IP,
+			"port": node.Port,
+		})
+	}
+	return list
+}
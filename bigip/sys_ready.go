@@ -0,0 +1,116 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+package bigip
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	bigip "github.com/efellowsbg/go-bigip"
+)
+
+const (
+	sysMcpStateURL = "/mgmt/tm/sys/mcp-state"
+	sysReadyURL    = "/mgmt/tm/sys/ready"
+)
+
+var sysReadyPollInterval = 10 * time.Second
+
+// statsEntry is an entry of the stats returned by /mgmt/tm/sys endpoints like ready or license.
+type statsEntry struct {
+	Description string `json:"description"`
+	NestedStats struct {
+		Entries statsMap `json:"entries"`
+	} `json:"nestedStats"`
+}
+
+type statsMap map[string]statsEntry
+
+// nested returns the entries nested in the first entry of the stats, e.g. in https://localhost/mgmt/tm/sys/ready/0.
+func (m statsMap) nested() statsMap {
+	for _, entry := range m {
+		return entry.NestedStats.Entries
+	}
+	return nil
+}
+
+// entry returns the entry of the stats named name. Nested stat collections are keyed by their self link,
+// e.g. https://localhost/mgmt/tm/sys/license/0/active_modules, and are matched by its last segment.
+func (m statsMap) entry(name string) statsEntry {
+	if entry, ok := m[name]; ok {
+		return entry
+	}
+	for key, entry := range m {
+		if strings.HasSuffix(key, "/"+name) {
+			return entry
+		}
+	}
+	return statsEntry{}
+}
+
+func getSysStats(client *bigip.BigIP, url string) (statsMap, error) {
+	resp, err := client.APICall(&bigip.APIRequest{
+		Method:      "get",
+		URL:         url,
+		ContentType: "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+	var stats struct {
+		Entries statsMap `json:"entries"`
+	}
+	if err := json.Unmarshal(resp, &stats); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", url, err)
+	}
+	return stats.Entries.nested(), nil
+}
+
+// bigipReady reports whether mcpd is running and the configuration, license and provisioning are ready, and
+// otherwise what is not.
+func bigipReady(client *bigip.BigIP) (bool, string, error) {
+	mcpState, err := getSysStats(client, sysMcpStateURL)
+	if err != nil {
+		return false, "", err
+	}
+	if phase := mcpState["phase"].Description; phase != "running" {
+		return false, fmt.Sprintf("mcpd phase is %s", phase), nil
+	}
+	ready, err := getSysStats(client, sysReadyURL)
+	if err != nil {
+		return false, "", err
+	}
+	for _, key := range []string{"configReady", "licenseReady", "provisionReady"} {
+		if ready[key].Description != "yes" {
+			return false, fmt.Sprintf("%s is %s", key, ready[key].Description), nil
+		}
+	}
+	return true, "", nil
+}
+
+// waitBigipReady polls the BIG-IP until mcpd is running and it is ready. Errors are expected while services
+// restart, e.g. after a license is installed, so they are retried until ctx expires.
+func waitBigipReady(ctx context.Context, client *bigip.BigIP) error {
+	for {
+		ready, reason, err := bigipReady(client)
+		if ready {
+			return nil
+		}
+		if err != nil {
+			reason = err.Error()
+		}
+		log.Printf("[DEBUG] Waiting for BIG-IP %s to be ready: %s", client.Host, reason)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out waiting for BIG-IP %s to be ready, %s: %v", client.Host, reason, ctx.Err())
+		case <-time.After(sysReadyPollInterval):
+		}
+	}
+}
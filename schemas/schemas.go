@@ -0,0 +1,17 @@
+/*
+Copyright 2026 F5 Networks Inc.
+This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0.
+If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*/
+
+// Package schemas embeds the JSON schemas used to validate AS3 and DO declarations without a BIG-IP,
+// and the AS3 class defaults used to compare declarations.
+package schemas
+
+import "embed"
+
+// FS holds doschema.json, the Declarative Onboarding base schema, as3schema.json, the structure
+// of an AS3 ADC declaration, and as3defaults.json, the property defaults of the AS3 classes.
+//
+//go:embed *.json
+var FS embed.FS